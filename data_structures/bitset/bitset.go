@@ -0,0 +1,320 @@
+package bitset
+
+import (
+	"algoritms_and_structures/data_structures/set"
+	"iter"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+const wordBits = 64
+
+// BitSet stores small non-negative integers as bits of 64-bit words,
+// it grows to fit the largest value and bits past the end read as clear
+type BitSet struct {
+	words []uint64
+}
+
+func wordIndex(i uint) (int, uint64) {
+	return int(i / wordBits), 1 << (i % wordBits)
+}
+
+func (b *BitSet) grow(words int) {
+	if words > len(b.words) {
+		b.words = append(b.words, make([]uint64, words-len(b.words))...)
+	}
+}
+
+// trim drops trailing zero words so Len stays tight after clearing
+func (b *BitSet) trim() {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	b.words = b.words[:n]
+}
+
+// Len returns the number of bits the words can hold
+func (b *BitSet) Len() uint {
+	return uint(len(b.words)) * wordBits
+}
+
+func (b *BitSet) Set(i uint) {
+	w, mask := wordIndex(i)
+	b.grow(w + 1)
+	b.words[w] |= mask
+}
+
+func (b *BitSet) Clear(i uint) {
+	w, mask := wordIndex(i)
+	if w < len(b.words) {
+		b.words[w] &^= mask
+		b.trim()
+	}
+}
+
+func (b *BitSet) Flip(i uint) {
+	w, mask := wordIndex(i)
+	b.grow(w + 1)
+	b.words[w] ^= mask
+	b.trim()
+}
+
+func (b *BitSet) Test(i uint) bool {
+	w, mask := wordIndex(i)
+	return w < len(b.words) && b.words[w]&mask != 0
+}
+
+// Add mirrors set.Set, it reports whether i was clear
+func (b *BitSet) Add(i uint) bool {
+	if b.Test(i) {
+		return false
+	}
+	b.Set(i)
+	return true
+}
+
+// Discard mirrors set.Set, it reports whether i was set
+func (b *BitSet) Discard(i uint) bool {
+	if !b.Test(i) {
+		return false
+	}
+	b.Clear(i)
+	return true
+}
+
+func (b *BitSet) Contains(i uint) bool {
+	return b.Test(i)
+}
+
+// Count returns the number of set bits
+func (b *BitSet) Count() uint {
+	var n int
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return uint(n)
+}
+
+func (b *BitSet) Size() int {
+	return int(b.Count())
+}
+
+func (b *BitSet) IsEmpty() bool {
+	for _, w := range b.words {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BitSet) RemoveAll() {
+	b.words = nil
+}
+
+// NextSet returns the first set bit at i or after it
+func (b *BitSet) NextSet(i uint) (uint, bool) {
+	w, _ := wordIndex(i)
+	if w >= len(b.words) {
+		return 0, false
+	}
+	word := b.words[w] >> (i % wordBits)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word)), true
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return uint(w)*wordBits + uint(bits.TrailingZeros64(b.words[w])), true
+		}
+	}
+	return 0, false
+}
+
+// NextClear returns the first clear bit at i or after it, bits past the end are clear
+func (b *BitSet) NextClear(i uint) uint {
+	w, _ := wordIndex(i)
+	if w >= len(b.words) {
+		return i
+	}
+	word := ^b.words[w] >> (i % wordBits)
+	if word != 0 {
+		return i + uint(bits.TrailingZeros64(word))
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != ^uint64(0) {
+			return uint(w)*wordBits + uint(bits.TrailingZeros64(^b.words[w]))
+		}
+	}
+	return b.Len()
+}
+
+// All iterates over the set bits in ascending order
+func (b *BitSet) All() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for w, word := range b.words {
+			for word != 0 {
+				if !yield(uint(w)*wordBits + uint(bits.TrailingZeros64(word))) {
+					return
+				}
+				word &= word - 1
+			}
+		}
+	}
+}
+
+func (b *BitSet) Slice() []uint {
+	values := make([]uint, 0, b.Count())
+	for i := range b.All() {
+		values = append(values, i)
+	}
+	return values
+}
+
+func (b *BitSet) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i := range b.All() {
+		if sb.Len() > 1 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(strconv.FormatUint(uint64(i), 10))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+func (b *BitSet) Equal(other *BitSet) bool {
+	short, long := b.words, other.words
+	if len(short) > len(long) {
+		short, long = long, short
+	}
+	for i := range short {
+		if short[i] != long[i] {
+			return false
+		}
+	}
+	for _, w := range long[len(short):] {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BitSet) IsSubset(other *BitSet) bool {
+	for i, w := range b.words {
+		var o uint64
+		if i < len(other.words) {
+			o = other.words[i]
+		}
+		if w&^o != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BitSet) IsSuperset(other *BitSet) bool {
+	return other.IsSubset(b)
+}
+
+func (b *BitSet) IsDisjoint(other *BitSet) bool {
+	for i := range min(len(b.words), len(other.words)) {
+		if b.words[i]&other.words[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *BitSet) UnionWith(other *BitSet) {
+	b.grow(len(other.words))
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+func (b *BitSet) IntersectWith(other *BitSet) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+	b.trim()
+}
+
+func (b *BitSet) DifferenceWith(other *BitSet) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+	b.trim()
+}
+
+func (b *BitSet) SymmetricDifferenceWith(other *BitSet) {
+	b.grow(len(other.words))
+	for i, w := range other.words {
+		b.words[i] ^= w
+	}
+	b.trim()
+}
+
+func (b *BitSet) Union(other *BitSet) *BitSet {
+	c := b.Clone()
+	c.UnionWith(other)
+	return c
+}
+
+func (b *BitSet) Intersect(other *BitSet) *BitSet {
+	c := b.Clone()
+	c.IntersectWith(other)
+	return c
+}
+
+func (b *BitSet) Difference(other *BitSet) *BitSet {
+	c := b.Clone()
+	c.DifferenceWith(other)
+	return c
+}
+
+func (b *BitSet) SymmetricDifference(other *BitSet) *BitSet {
+	c := b.Clone()
+	c.SymmetricDifferenceWith(other)
+	return c
+}
+
+func (b *BitSet) ToSet() *set.Set[uint] {
+	s := make(set.Set[uint], b.Count())
+	for i := range b.All() {
+		s.Add(i)
+	}
+	return &s
+}
+
+func FromSet(s *set.Set[uint]) *BitSet {
+	b := &BitSet{}
+	for i := range *s {
+		b.Set(i)
+	}
+	return b
+}
+
+func NewBitSet(values ...uint) *BitSet {
+	b := &BitSet{}
+	for _, i := range values {
+		b.Set(i)
+	}
+	return b
+}
+
+// NewBitSetWithCapacity preallocates words for bits [0, n)
+func NewBitSetWithCapacity(n uint) *BitSet {
+	return &BitSet{words: make([]uint64, 0, (n+wordBits-1)/wordBits)}
+}
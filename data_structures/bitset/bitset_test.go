@@ -0,0 +1,163 @@
+package bitset
+
+import (
+	"algoritms_and_structures/data_structures/set"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestBitSet_SetClearFlip(t *testing.T) {
+	b := NewBitSet()
+	assert.True(t, b.IsEmpty())
+	assert.False(t, b.Test(1000))
+
+	b.Set(3)
+	b.Set(130)
+	assert.True(t, b.Test(3))
+	assert.True(t, b.Test(130))
+	assert.False(t, b.Test(4))
+	assert.Equal(t, uint(192), b.Len())
+	assert.Equal(t, uint(2), b.Count())
+
+	b.Flip(3)
+	b.Flip(4)
+	assert.False(t, b.Test(3))
+	assert.True(t, b.Test(4))
+
+	b.Clear(130)
+	b.Clear(5000)
+	assert.Equal(t, uint(64), b.Len(), "trailing zero words must be dropped")
+	assert.Equal(t, []uint{4}, b.Slice())
+
+	assert.True(t, b.Add(7))
+	assert.False(t, b.Add(7))
+	assert.True(t, b.Discard(7))
+	assert.False(t, b.Discard(7))
+	assert.True(t, b.Contains(4))
+	assert.Equal(t, 1, b.Size())
+
+	b.RemoveAll()
+	assert.True(t, b.IsEmpty())
+	assert.Zero(t, b.Len())
+}
+
+func TestBitSet_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	b := NewBitSetWithCapacity(1000)
+	want := set.NewSet[uint]()
+	for i := 0; i < 5000; i++ {
+		v := uint(rnd.Intn(1000))
+		if rnd.Intn(3) == 0 {
+			assert.Equal(t, want.Discard(v), b.Discard(v))
+			continue
+		}
+		assert.Equal(t, want.Add(v), b.Add(v))
+	}
+	assert.Equal(t, uint(want.Size()), b.Count())
+	assert.Equal(t, want.Slice(), b.Slice())
+	assert.Equal(t, want, b.ToSet())
+	assert.True(t, b.Equal(FromSet(want)))
+}
+
+func TestBitSet_Next(t *testing.T) {
+	b := NewBitSet(0, 1, 2, 63, 64, 200)
+	type testCase struct {
+		name      string
+		from      uint
+		wantSet   uint
+		wantOk    bool
+		wantClear uint
+	}
+	tests := []testCase{
+		{name: "from start", from: 0, wantSet: 0, wantOk: true, wantClear: 3},
+		{name: "inside run", from: 1, wantSet: 1, wantOk: true, wantClear: 3},
+		{name: "across words", from: 3, wantSet: 63, wantOk: true, wantClear: 3},
+		{name: "word boundary", from: 63, wantSet: 63, wantOk: true, wantClear: 65},
+		{name: "skip empty word", from: 65, wantSet: 200, wantOk: true, wantClear: 65},
+		{name: "last", from: 200, wantSet: 200, wantOk: true, wantClear: 201},
+		{name: "past the end", from: 1000, wantClear: 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := b.NextSet(tt.from)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantSet, got)
+			assert.Equal(t, tt.wantClear, b.NextClear(tt.from))
+		})
+	}
+	_, ok := b.NextSet(201)
+	assert.False(t, ok)
+
+	full := NewBitSet()
+	for i := uint(0); i < 128; i++ {
+		full.Set(i)
+	}
+	assert.Equal(t, uint(128), full.NextClear(0))
+}
+
+func TestBitSet_Algebra(t *testing.T) {
+	a := NewBitSet(1, 2, 3, 100)
+	b := NewBitSet(3, 4, 300)
+	sa, sb := a.ToSet(), b.ToSet()
+	type testCase struct {
+		name string
+		got  *BitSet
+		want *set.Set[uint]
+	}
+	// results must match set.Set
+	tests := []testCase{
+		{name: "union", got: a.Union(b), want: sa.Union(sb)},
+		{name: "intersect", got: a.Intersect(b), want: sa.Intersect(sb)},
+		{name: "intersect shorter", got: b.Intersect(a), want: sb.Intersect(sa)},
+		{name: "difference", got: a.Difference(b), want: sa.Difference(sb)},
+		{name: "difference longer", got: b.Difference(a), want: sb.Difference(sa)},
+		{name: "symmetric difference", got: a.SymmetricDifference(b), want: sa.SymmetricDifference(sb)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got.ToSet())
+		})
+	}
+	assert.Equal(t, []uint{1, 2, 3, 100}, a.Slice(), "operations must not change the source")
+
+	c := a.Clone()
+	c.UnionWith(b)
+	c.DifferenceWith(NewBitSet(300))
+	c.IntersectWith(NewBitSet(1, 4, 100))
+	assert.Equal(t, []uint{1, 4, 100}, c.Slice())
+	c.SymmetricDifferenceWith(NewBitSet(100))
+	assert.Equal(t, uint(64), c.Len())
+}
+
+func TestBitSet_Predicates(t *testing.T) {
+	a := NewBitSet(1, 2, 200)
+	assert.True(t, NewBitSet(1, 200).IsSubset(a))
+	assert.False(t, NewBitSet(1, 300).IsSubset(a))
+	assert.True(t, a.IsSuperset(NewBitSet(2)))
+	assert.True(t, a.IsDisjoint(NewBitSet(3, 300)))
+	assert.False(t, a.IsDisjoint(NewBitSet(200)))
+
+	// trailing zero words must not affect Equal
+	b := NewBitSet(1, 2, 200, 500)
+	b.words[len(b.words)-1] = 0
+	assert.True(t, a.Equal(b))
+	assert.True(t, b.Equal(a))
+	assert.False(t, a.Equal(NewBitSet(1, 2)))
+	assert.True(t, NewBitSet().Equal(NewBitSet()))
+}
+
+func TestBitSet_String(t *testing.T) {
+	assert.Equal(t, "{}", NewBitSet().String())
+	assert.Equal(t, "{0, 5, 64}", NewBitSet(64, 5, 0).String())
+	assert.Equal(t, NewBitSet(3, 1).String(), set.NewSet[uint](1, 3).String())
+
+	var first []uint
+	for i := range NewBitSet(1, 2, 3).All() {
+		first = append(first, i)
+		if len(first) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []uint{1, 2}, first)
+}
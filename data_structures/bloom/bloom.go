@@ -0,0 +1,151 @@
+package bloom
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+const wordBits = 64
+
+// hashing derives k bit positions from a single hash by double hashing
+type hashing[K comparable] struct {
+	m      uint64
+	k      uint64
+	hasher hash_table.Hasher[K]
+}
+
+// newHashing takes the hasher from hash_table.WithHasher, hash_table.FNVHasher by default
+func newHashing[K comparable](m, k uint64, opts []hash_table.Option[K]) hashing[K] {
+	return hashing[K]{m: m, k: k, hasher: hash_table.HasherFrom(opts...)}
+}
+
+func (h *hashing[K]) hashes(key K) (uint64, uint64, error) {
+	h1, err := h.hasher.Hash(key)
+	if err != nil {
+		return 0, 0, err
+	}
+	// an odd step is never 0, positions repeat after m/gcd(h2, m) steps,
+	// at least 64 of them when m is a multiple of 64 as OptimalSize makes it
+	return h1, hash_table.Mix(h1) | 1, nil
+}
+
+// wordCount rounds m bits up to whole words, it doesn't overflow for m close to 2^64
+func wordCount(m uint64) uint64 {
+	words := m / wordBits
+	if m%wordBits != 0 {
+		words++
+	}
+	return words
+}
+
+func (h *hashing[K]) position(h1, h2, i uint64) uint64 {
+	return (h1 + i*h2) % h.m
+}
+
+var (
+	ErrCapacityIsEmpty          = errors.New("expected items cannot be 0")
+	ErrInvalidFalsePositiveRate = errors.New("false positive rate must be in (0, 1)")
+	ErrInvalidSize              = errors.New("bits and hash functions cannot be 0")
+)
+
+// OptimalSize returns bits and hash functions count for n items with false positive rate p,
+// bits are rounded up to whole 64-bit words
+func OptimalSize(n uint64, p float64) (uint64, uint64, error) {
+	if n < 1 {
+		return 0, 0, ErrCapacityIsEmpty
+	}
+	if p <= 0 || p >= 1 {
+		return 0, 0, ErrInvalidFalsePositiveRate
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	m = wordCount(m) * wordBits
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	return m, max(k, 1), nil
+}
+
+// Filter answers false only for keys which were never added
+type Filter[K comparable] struct {
+	hashing[K]
+	words []uint64
+}
+
+// Bits returns the filter length m
+func (f *Filter[K]) Bits() uint64 {
+	return f.m
+}
+
+func (f *Filter[K]) HashFunctions() uint64 {
+	return f.k
+}
+
+func (f *Filter[K]) Add(key K) error {
+	h1, h2, err := f.hashes(key)
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < f.k; i++ {
+		p := f.position(h1, h2, i)
+		f.words[p/wordBits] |= 1 << (p % wordBits)
+	}
+	return nil
+}
+
+func (f *Filter[K]) MayContain(key K) (bool, error) {
+	h1, h2, err := f.hashes(key)
+	if err != nil {
+		return false, err
+	}
+	for i := uint64(0); i < f.k; i++ {
+		p := f.position(h1, h2, i)
+		if f.words[p/wordBits]&(1<<(p%wordBits)) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// FalsePositiveRate estimates the current rate from the share of set bits
+func (f *Filter[K]) FalsePositiveRate() float64 {
+	var ones int
+	for _, w := range f.words {
+		ones += bits.OnesCount64(w)
+	}
+	return math.Pow(float64(ones)/float64(f.m), float64(f.k))
+}
+
+var ErrIncompatibleFilters = errors.New("filters must have the same bits and hash functions")
+
+// Union adds all keys of other to f, both filters must use the same hasher
+func (f *Filter[K]) Union(other *Filter[K]) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrIncompatibleFilters
+	}
+	for i := range f.words {
+		f.words[i] |= other.words[i]
+	}
+	return nil
+}
+
+func (f *Filter[K]) Clear() {
+	clear(f.words)
+}
+
+func NewFilter[K comparable](n uint64, p float64, opts ...hash_table.Option[K]) (*Filter[K], error) {
+	m, k, err := OptimalSize(n, p)
+	if err != nil {
+		return nil, err
+	}
+	return NewFilterWithSize[K](m, k, opts...)
+}
+
+func NewFilterWithSize[K comparable](m, k uint64, opts ...hash_table.Option[K]) (*Filter[K], error) {
+	if m < 1 || k < 1 {
+		return nil, ErrInvalidSize
+	}
+	return &Filter[K]{
+		hashing: newHashing(m, k, opts),
+		words:   make([]uint64, wordCount(m)),
+	}, nil
+}
@@ -0,0 +1,112 @@
+package bloom
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestOptimalSize(t *testing.T) {
+	type testCase struct {
+		name    string
+		n       uint64
+		p       float64
+		wantM   uint64
+		wantK   uint64
+		wantErr error
+	}
+	tests := []testCase{
+		{name: "got error when empty", n: 0, p: 0.01, wantErr: ErrCapacityIsEmpty},
+		{name: "got error with zero rate", n: 10, p: 0, wantErr: ErrInvalidFalsePositiveRate},
+		{name: "got error with rate one", n: 10, p: 1, wantErr: ErrInvalidFalsePositiveRate},
+		{name: "one percent", n: 1000, p: 0.01, wantM: 9600, wantK: 7},
+		{name: "rounded to word", n: 1, p: 0.5, wantM: 64, wantK: 44},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, k, err := OptimalSize(tt.n, tt.p)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantM, m)
+			assert.Equal(t, tt.wantK, k)
+		})
+	}
+}
+
+func TestNewFilterWithSize(t *testing.T) {
+	_, err := NewFilterWithSize[int](0, 1)
+	assert.ErrorIs(t, err, ErrInvalidSize)
+	_, err = NewFilterWithSize[int](64, 0)
+	assert.ErrorIs(t, err, ErrInvalidSize)
+
+	f, err := NewFilterWithSize[int](100, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), f.Bits())
+	assert.Equal(t, uint64(3), f.HashFunctions())
+	assert.Len(t, f.words, 2)
+}
+
+func TestFilter_MayContain(t *testing.T) {
+	const n = 10000
+	f, err := NewFilter[string](n, 0.01)
+	assert.NoError(t, err)
+	for i := 0; i < n; i++ {
+		assert.NoError(t, f.Add(fmt.Sprintf("key-%d", i)))
+	}
+	for i := 0; i < n; i++ {
+		ok, err := f.MayContain(fmt.Sprintf("key-%d", i))
+		assert.NoError(t, err)
+		assert.True(t, ok, "added keys are never reported as absent")
+	}
+	var falsePositives int
+	for i := 0; i < n; i++ {
+		if ok, _ := f.MayContain(fmt.Sprintf("other-%d", i)); ok {
+			falsePositives++
+		}
+	}
+	assert.Less(t, float64(falsePositives)/n, 0.02)
+	assert.InDelta(t, 0.01, f.FalsePositiveRate(), 0.005)
+
+	f.Clear()
+	ok, _ := f.MayContain("key-1")
+	assert.False(t, ok)
+	assert.Zero(t, f.FalsePositiveRate())
+}
+
+func TestFilter_Union(t *testing.T) {
+	a, _ := NewFilter[int](100, 0.01)
+	b, _ := NewFilter[int](100, 0.01)
+	for i := 0; i < 50; i++ {
+		_ = a.Add(i)
+		_ = b.Add(i + 50)
+	}
+	assert.NoError(t, a.Union(b))
+	for i := 0; i < 100; i++ {
+		ok, _ := a.MayContain(i)
+		assert.Truef(t, ok, "MayContain(%v)", i)
+	}
+
+	c, _ := NewFilter[int](1000, 0.01)
+	assert.ErrorIs(t, a.Union(c), ErrIncompatibleFilters)
+}
+
+func TestFilter_HasherError(t *testing.T) {
+	h := hash_table.MockHasher[int]{}
+	h.On("Hash", mock.AnythingOfType("int")).
+		Return(uint64(0), errors.New("test err"))
+	f, _ := NewFilter[int](10, 0.1, hash_table.WithHasher[int](&h))
+
+	assert.Error(t, f.Add(1))
+	_, err := f.MayContain(1)
+	assert.Error(t, err)
+}
+
+func TestFilter_WithHasher(t *testing.T) {
+	f, _ := NewFilter[string](10, 0.1, hash_table.WithHasher[string](hash_table.StringHasher[string]{}), hash_table.WithHasher[string](nil))
+	assert.IsType(t, hash_table.StringHasher[string]{}, f.hasher)
+	_ = f.Add("a")
+	ok, _ := f.MayContain("a")
+	assert.True(t, ok)
+}
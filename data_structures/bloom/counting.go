@@ -0,0 +1,111 @@
+package bloom
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"errors"
+	"math"
+)
+
+// CountingFilter keeps a counter per position so keys can be removed.
+// A saturated counter is never decremented, otherwise it could produce false negatives
+type CountingFilter[K comparable] struct {
+	hashing[K]
+	counters []uint8
+}
+
+func (f *CountingFilter[K]) Bits() uint64 {
+	return f.m
+}
+
+func (f *CountingFilter[K]) HashFunctions() uint64 {
+	return f.k
+}
+
+func (f *CountingFilter[K]) Add(key K) error {
+	h1, h2, err := f.hashes(key)
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < f.k; i++ {
+		p := f.position(h1, h2, i)
+		if f.counters[p] < math.MaxUint8 {
+			f.counters[p]++
+		}
+	}
+	return nil
+}
+
+func (f *CountingFilter[K]) MayContain(key K) (bool, error) {
+	h1, h2, err := f.hashes(key)
+	if err != nil {
+		return false, err
+	}
+	for i := uint64(0); i < f.k; i++ {
+		if f.counters[f.position(h1, h2, i)] == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+var ErrElementIsNotFound = errors.New("element was never added")
+
+// Remove must be called only for added keys, removing a false positive breaks other keys
+func (f *CountingFilter[K]) Remove(key K) error {
+	ok, err := f.MayContain(key)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrElementIsNotFound
+	}
+	h1, h2, _ := f.hashes(key)
+	for i := uint64(0); i < f.k; i++ {
+		p := f.position(h1, h2, i)
+		if f.counters[p] < math.MaxUint8 {
+			f.counters[p]--
+		}
+	}
+	return nil
+}
+
+// Union sums the counters, so keys of both filters can still be removed
+func (f *CountingFilter[K]) Union(other *CountingFilter[K]) error {
+	if f.m != other.m || f.k != other.k {
+		return ErrIncompatibleFilters
+	}
+	for i, c := range other.counters {
+		f.counters[i] = uint8(min(uint64(f.counters[i])+uint64(c), math.MaxUint8))
+	}
+	return nil
+}
+
+// Filter returns a standard filter with the same keys
+func (f *CountingFilter[K]) Filter() *Filter[K] {
+	bf := &Filter[K]{hashing: f.hashing, words: make([]uint64, wordCount(f.m))}
+	for p, c := range f.counters {
+		if c > 0 {
+			bf.words[p/wordBits] |= 1 << (p % wordBits)
+		}
+	}
+	return bf
+}
+
+func (f *CountingFilter[K]) Clear() {
+	clear(f.counters)
+}
+
+func NewCountingFilter[K comparable](n uint64, p float64, opts ...hash_table.Option[K]) (*CountingFilter[K], error) {
+	m, k, err := OptimalSize(n, p)
+	if err != nil {
+		return nil, err
+	}
+	return NewCountingFilterWithSize[K](m, k, opts...)
+}
+
+func NewCountingFilterWithSize[K comparable](m, k uint64, opts ...hash_table.Option[K]) (*CountingFilter[K], error) {
+	if m < 1 || k < 1 {
+		return nil, ErrInvalidSize
+	}
+	return &CountingFilter[K]{hashing: newHashing(m, k, opts), counters: make([]uint8, m)}, nil
+}
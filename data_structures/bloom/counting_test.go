@@ -0,0 +1,88 @@
+package bloom
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestCountingFilter_Remove(t *testing.T) {
+	f, err := NewCountingFilter[int](1000, 0.01)
+	assert.NoError(t, err)
+	for i := 0; i < 1000; i++ {
+		assert.NoError(t, f.Add(i))
+	}
+	for i := 0; i < 1000; i += 2 {
+		assert.NoError(t, f.Remove(i))
+	}
+	for i := 1; i < 1000; i += 2 {
+		ok, err := f.MayContain(i)
+		assert.NoError(t, err)
+		assert.Truef(t, ok, "MayContain(%v)", i)
+	}
+	var present int
+	for i := 0; i < 1000; i += 2 {
+		if ok, _ := f.MayContain(i); ok {
+			present++
+		}
+	}
+	assert.Less(t, present, 25, "removed keys must be mostly reported as absent")
+
+	f.Clear()
+	assert.ErrorIs(t, f.Remove(1), ErrElementIsNotFound)
+}
+
+func TestCountingFilter_Duplicates(t *testing.T) {
+	f, _ := NewCountingFilterWithSize[string](64, 3)
+	_ = f.Add("a")
+	_ = f.Add("a")
+	assert.NoError(t, f.Remove("a"))
+	ok, _ := f.MayContain("a")
+	assert.True(t, ok, "a was added twice")
+	assert.NoError(t, f.Remove("a"))
+	ok, _ = f.MayContain("a")
+	assert.False(t, ok)
+}
+
+func TestCountingFilter_Saturation(t *testing.T) {
+	f, _ := NewCountingFilterWithSize[string](64, 2)
+	for i := 0; i < math.MaxUint8+10; i++ {
+		_ = f.Add("a")
+	}
+	for i := 0; i < math.MaxUint8+10; i++ {
+		assert.NoError(t, f.Remove("a"))
+	}
+	ok, _ := f.MayContain("a")
+	assert.True(t, ok, "saturated counters must stay set")
+}
+
+func TestCountingFilter_Union(t *testing.T) {
+	a, _ := NewCountingFilter[int](100, 0.01)
+	b, _ := NewCountingFilter[int](100, 0.01)
+	_ = a.Add(1)
+	_ = b.Add(1)
+	_ = b.Add(2)
+	assert.NoError(t, a.Union(b))
+	assert.NoError(t, a.Remove(1))
+	ok, _ := a.MayContain(1)
+	assert.True(t, ok, "counters of both filters are summed")
+	ok, _ = a.MayContain(2)
+	assert.True(t, ok)
+
+	c, _ := NewCountingFilter[int](10, 0.5)
+	assert.ErrorIs(t, a.Union(c), ErrIncompatibleFilters)
+}
+
+func TestCountingFilter_Filter(t *testing.T) {
+	cf, _ := NewCountingFilter[int](100, 0.01)
+	for i := 0; i < 100; i++ {
+		_ = cf.Add(i)
+	}
+	f := cf.Filter()
+	assert.Equal(t, cf.Bits(), f.Bits())
+	assert.Equal(t, cf.HashFunctions(), f.HashFunctions())
+	for i := 0; i < 100; i++ {
+		ok, _ := f.MayContain(i)
+		assert.True(t, ok)
+	}
+}
@@ -0,0 +1,84 @@
+package bloom
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"encoding/binary"
+	"errors"
+)
+
+// binary layout: kind byte, m and k as little endian uint64, then the words or counters.
+// The hasher isn't encoded, a decoded filter keeps the one of the receiver
+// and a zero value filter gets hash_table.FNVHasher
+const (
+	kindFilter byte = iota + 1
+	kindCountingFilter
+	headerSize = 1 + 8 + 8
+)
+
+var ErrInvalidData = errors.New("invalid filter data")
+
+func (h *hashing[K]) appendHeader(kind byte, size int) []byte {
+	data := make([]byte, 0, headerSize+size)
+	data = append(data, kind)
+	data = binary.LittleEndian.AppendUint64(data, h.m)
+	return binary.LittleEndian.AppendUint64(data, h.k)
+}
+
+func (h *hashing[K]) readHeader(kind byte, data []byte) ([]byte, error) {
+	if len(data) < headerSize || data[0] != kind {
+		return nil, ErrInvalidData
+	}
+	m, k := binary.LittleEndian.Uint64(data[1:]), binary.LittleEndian.Uint64(data[9:])
+	if m < 1 || k < 1 {
+		return nil, ErrInvalidData
+	}
+	h.m, h.k = m, k
+	if h.hasher == nil {
+		h.hasher = hash_table.HasherFrom[K]()
+	}
+	return data[headerSize:], nil
+}
+
+func (f *Filter[K]) MarshalBinary() ([]byte, error) {
+	data := f.appendHeader(kindFilter, len(f.words)*8)
+	for _, w := range f.words {
+		data = binary.LittleEndian.AppendUint64(data, w)
+	}
+	return data, nil
+}
+
+func (f *Filter[K]) UnmarshalBinary(data []byte) error {
+	h := f.hashing
+	payload, err := h.readHeader(kindFilter, data)
+	if err != nil {
+		return err
+	}
+	words := wordCount(h.m)
+	if uint64(len(payload))%8 != 0 || uint64(len(payload))/8 != words {
+		return ErrInvalidData
+	}
+	f.hashing = h
+	f.words = make([]uint64, words)
+	for i := range f.words {
+		f.words[i] = binary.LittleEndian.Uint64(payload[i*8:])
+	}
+	return nil
+}
+
+func (f *CountingFilter[K]) MarshalBinary() ([]byte, error) {
+	return append(f.appendHeader(kindCountingFilter, len(f.counters)), f.counters...), nil
+}
+
+func (f *CountingFilter[K]) UnmarshalBinary(data []byte) error {
+	h := f.hashing
+	payload, err := h.readHeader(kindCountingFilter, data)
+	if err != nil {
+		return err
+	}
+	if uint64(len(payload)) != h.m {
+		return ErrInvalidData
+	}
+	f.hashing = h
+	f.counters = append([]uint8(nil), payload...)
+	return nil
+}
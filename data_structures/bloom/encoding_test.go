@@ -0,0 +1,75 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFilter_MarshalBinary(t *testing.T) {
+	f, _ := NewFilter[int](100, 0.01)
+	for i := 0; i < 100; i++ {
+		_ = f.Add(i)
+	}
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	var got Filter[int]
+	assert.NoError(t, got.UnmarshalBinary(data))
+	assert.Equal(t, f.Bits(), got.Bits())
+	assert.Equal(t, f.HashFunctions(), got.HashFunctions())
+	assert.Equal(t, f.words, got.words)
+	for i := 0; i < 100; i++ {
+		ok, _ := got.MayContain(i)
+		assert.True(t, ok)
+	}
+	assert.NoError(t, got.Union(f))
+}
+
+func TestCountingFilter_MarshalBinary(t *testing.T) {
+	f, _ := NewCountingFilter[string](10, 0.1)
+	_ = f.Add("a")
+	_ = f.Add("a")
+	data, err := f.MarshalBinary()
+	assert.NoError(t, err)
+
+	var got CountingFilter[string]
+	assert.NoError(t, got.UnmarshalBinary(data))
+	assert.Equal(t, f.counters, got.counters)
+	assert.NoError(t, got.Remove("a"))
+	ok, _ := got.MayContain("a")
+	assert.True(t, ok)
+}
+
+func TestUnmarshalBinary_InvalidData(t *testing.T) {
+	f, _ := NewFilter[int](100, 0.01)
+	data, _ := f.MarshalBinary()
+	cf, _ := NewCountingFilter[int](100, 0.01)
+	countingData, _ := cf.MarshalBinary()
+
+	tests := map[string][]byte{
+		"empty":                 nil,
+		"short header":          data[:headerSize-1],
+		"short payload":         data[:len(data)-1],
+		"other kind":            countingData,
+		"zero size":             append([]byte{kindFilter}, make([]byte, headerSize-1)...),
+		"wrapping size":         header(kindFilter, ^uint64(0), 1),
+		"size of one word more": append(header(kindFilter, 65, 1), make([]byte, 8)...),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got Filter[int]
+			assert.ErrorIs(t, got.UnmarshalBinary(data), ErrInvalidData)
+			assert.Nil(t, got.words)
+		})
+	}
+
+	var got CountingFilter[int]
+	assert.ErrorIs(t, got.UnmarshalBinary(data), ErrInvalidData)
+	assert.ErrorIs(t, got.UnmarshalBinary(countingData[:len(countingData)-1]), ErrInvalidData)
+}
+
+func header(kind byte, m, k uint64) []byte {
+	data := binary.LittleEndian.AppendUint64([]byte{kind}, m)
+	return binary.LittleEndian.AppendUint64(data, k)
+}
@@ -0,0 +1,296 @@
+package cache
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"algoritms_and_structures/data_structures/lists"
+	"errors"
+	"sync"
+	"time"
+)
+
+type EvictionReason uint8
+
+const (
+	EvictedByCapacity EvictionReason = iota
+	EvictedByExpiration
+	EvictedByPurge
+)
+
+type EvictFunc[K comparable, V any] func(key K, value V, reason EvictionReason)
+
+type CostFunc[V any] func(value V) uint64
+
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+}
+
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+type entry[K comparable, V any] struct {
+	key      K
+	value    V
+	cost     uint64
+	expireAt time.Time
+	freq     uint64
+	node     *lists.DoubleNode[*entry[K, V]]
+}
+
+func (e *entry[K, V]) expired(now time.Time) bool {
+	return !e.expireAt.IsZero() && !now.Before(e.expireAt)
+}
+
+// policy decides which entry leaves the cache first
+type policy[K comparable, V any] interface {
+	add(e *entry[K, V])
+	touch(e *entry[K, V])
+	remove(e *entry[K, V])
+	victim() *entry[K, V]
+}
+
+type Cache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity uint64
+	cost     uint64
+	ttl      time.Duration
+	items    *hash_table.HashTable[K, *entry[K, V]]
+	policy   policy[K, V]
+	onEvict  EvictFunc[K, V]
+	costFunc CostFunc[V]
+	now      func() time.Time
+	stats    Stats
+}
+
+type Option[K comparable, V any] func(c *Cache[K, V])
+
+// WithTTL sets the default time to live, zero means entries never expire
+func WithTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.ttl = ttl
+	}
+}
+
+func WithOnEvict[K comparable, V any](f EvictFunc[K, V]) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onEvict = f
+	}
+}
+
+// WithCost makes capacity a cost bound instead of an entries bound
+func WithCost[K comparable, V any](f CostFunc[V]) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.costFunc = f
+	}
+}
+
+func WithHasher[K comparable, V any](hasher hash_table.Hasher[K]) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.items = hash_table.NewHashTable[K, *entry[K, V]](hash_table.WithHasher(hasher))
+	}
+}
+
+func WithClock[K comparable, V any](now func() time.Time) Option[K, V] {
+	return func(c *Cache[K, V]) {
+		c.now = now
+	}
+}
+
+type evicted[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictionReason
+}
+
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return int(c.items.Size())
+}
+
+func (c *Cache[K, V]) Cost() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cost
+}
+
+func (c *Cache[K, V]) Capacity() uint64 {
+	return c.capacity
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	e, ok := c.items.Lookup(key)
+	if !ok {
+		c.stats.Misses++
+		c.mu.Unlock()
+		return *new(V), false
+	}
+	if e.expired(c.now()) {
+		c.stats.Misses++
+		c.stats.Expirations++
+		c.delete(e)
+		c.mu.Unlock()
+		c.notify([]evicted[K, V]{{key: e.key, value: e.value, reason: EvictedByExpiration}})
+		return *new(V), false
+	}
+	c.stats.Hits++
+	c.policy.touch(e)
+	c.mu.Unlock()
+	return e.value, true
+}
+
+// Peek returns the value without updating recency, frequency or statistics
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items.Lookup(key)
+	if !ok || e.expired(c.now()) {
+		return *new(V), false
+	}
+	return e.value, true
+}
+
+func (c *Cache[K, V]) Contains(key K) bool {
+	_, ok := c.Peek(key)
+	return ok
+}
+
+func (c *Cache[K, V]) Put(key K, value V) error {
+	return c.PutWithTTL(key, value, c.ttl)
+}
+
+var ErrCostExceedsCapacity = errors.New("entry cost exceeds cache capacity")
+
+// PutWithTTL overrides the default time to live for a single entry
+func (c *Cache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) error {
+	cost := uint64(1)
+	if c.costFunc != nil {
+		cost = c.costFunc(value)
+	}
+	if cost > c.capacity {
+		return ErrCostExceedsCapacity
+	}
+	var expireAt time.Time
+	if ttl > 0 {
+		expireAt = c.now().Add(ttl)
+	}
+
+	c.mu.Lock()
+	if e, ok := c.items.Lookup(key); ok {
+		// take the entry out of the policy, so making room never evicts it
+		c.policy.remove(e)
+		c.cost -= e.cost
+		removed := c.evict(cost)
+		e.value, e.cost, e.expireAt = value, cost, expireAt
+		c.cost += cost
+		c.policy.add(e)
+		c.policy.touch(e)
+		c.mu.Unlock()
+		c.notify(removed)
+		return nil
+	}
+	removed := c.evict(cost)
+	e := &entry[K, V]{key: key, value: value, cost: cost, expireAt: expireAt}
+	e.node = lists.NewDoubleNode(e)
+	if err := c.items.Put(key, e); err != nil {
+		c.mu.Unlock()
+		c.notify(removed)
+		return err
+	}
+	c.cost += cost
+	c.policy.add(e)
+	c.mu.Unlock()
+	c.notify(removed)
+	return nil
+}
+
+func (c *Cache[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.items.Lookup(key)
+	if !ok {
+		return false
+	}
+	c.delete(e)
+	return true
+}
+
+// Purge drops every entry, the callback gets them in eviction order with EvictedByPurge
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	var removed []evicted[K, V]
+	for e := c.policy.victim(); e != nil; e = c.policy.victim() {
+		c.delete(e)
+		removed = append(removed, evicted[K, V]{key: e.key, value: e.value, reason: EvictedByPurge})
+	}
+	c.mu.Unlock()
+	c.notify(removed)
+}
+
+// evict drops entries until need more cost fits the capacity
+func (c *Cache[K, V]) evict(need uint64) []evicted[K, V] {
+	var removed []evicted[K, V]
+	for c.cost+need > c.capacity {
+		e := c.policy.victim()
+		if e == nil {
+			break
+		}
+		reason := EvictedByCapacity
+		if e.expired(c.now()) {
+			reason = EvictedByExpiration
+			c.stats.Expirations++
+		} else {
+			c.stats.Evictions++
+		}
+		c.delete(e)
+		removed = append(removed, evicted[K, V]{key: e.key, value: e.value, reason: reason})
+	}
+	return removed
+}
+
+func (c *Cache[K, V]) delete(e *entry[K, V]) {
+	_ = c.items.Remove(e.key)
+	c.policy.remove(e)
+	c.cost -= e.cost
+}
+
+// notify runs callbacks outside the lock so they may use the cache
+func (c *Cache[K, V]) notify(removed []evicted[K, V]) {
+	if c.onEvict == nil {
+		return
+	}
+	for _, ev := range removed {
+		c.onEvict(ev.key, ev.value, ev.reason)
+	}
+}
+
+var ErrCapacityIsEmpty = errors.New("capacity cannot be 0")
+
+func newCache[K comparable, V any](capacity uint64, p policy[K, V], opts []Option[K, V]) (*Cache[K, V], error) {
+	if capacity < 1 {
+		return nil, ErrCapacityIsEmpty
+	}
+	c := &Cache[K, V]{
+		capacity: capacity,
+		items:    hash_table.NewHashTable[K, *entry[K, V]](),
+		policy:   p,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+type constructor func(capacity uint64, opts ...Option[string, int]) (*Cache[string, int], error)
+
+var constructors = map[string]constructor{
+	"lru": NewLRU[string, int],
+	"lfu": NewLFU[string, int],
+}
+
+func TestNewCache(t *testing.T) {
+	for name, newCache := range constructors {
+		t.Run(name, func(t *testing.T) {
+			_, err := newCache(0)
+			assert.ErrorIs(t, err, ErrCapacityIsEmpty)
+
+			c, err := newCache(2)
+			assert.NoError(t, err)
+			assert.Equal(t, uint64(2), c.Capacity())
+			assert.Zero(t, c.Len())
+		})
+	}
+}
+
+func TestCache_PutGet(t *testing.T) {
+	for name, newCache := range constructors {
+		t.Run(name, func(t *testing.T) {
+			c, _ := newCache(3)
+			assert.NoError(t, c.Put("a", 1))
+			assert.NoError(t, c.Put("b", 2))
+			assert.NoError(t, c.Put("a", 10))
+			assert.Equal(t, 2, c.Len())
+
+			got, ok := c.Get("a")
+			assert.True(t, ok)
+			assert.Equal(t, 10, got)
+			_, ok = c.Get("c")
+			assert.False(t, ok)
+
+			assert.True(t, c.Remove("a"))
+			assert.False(t, c.Remove("a"))
+			assert.False(t, c.Contains("a"))
+			assert.Equal(t, 1, c.Len())
+
+			c.Purge()
+			assert.Zero(t, c.Len())
+			assert.Zero(t, c.Cost())
+		})
+	}
+}
+
+func TestCache_Stats(t *testing.T) {
+	for name, newCache := range constructors {
+		t.Run(name, func(t *testing.T) {
+			c, _ := newCache(1)
+			_ = c.Put("a", 1)
+			c.Get("a")
+			c.Get("a")
+			c.Get("b")
+			_ = c.Put("b", 2)
+			c.Peek("a")
+
+			want := Stats{Hits: 2, Misses: 1, Evictions: 1}
+			assert.Equal(t, want, c.Stats())
+			assert.InDelta(t, 2.0/3.0, c.Stats().HitRatio(), 1e-9)
+			assert.Zero(t, Stats{}.HitRatio())
+		})
+	}
+}
+
+func TestCache_TTL(t *testing.T) {
+	for name, newCache := range constructors {
+		t.Run(name, func(t *testing.T) {
+			clock := &fakeClock{now: time.Unix(0, 0)}
+			var expired []string
+			c, _ := newCache(10,
+				WithTTL[string, int](time.Minute),
+				WithClock[string, int](clock.Now),
+				WithOnEvict(func(key string, value int, reason EvictionReason) {
+					if reason == EvictedByExpiration {
+						expired = append(expired, key)
+					}
+				}),
+			)
+			_ = c.Put("default", 1)
+			_ = c.PutWithTTL("short", 2, time.Second)
+			_ = c.PutWithTTL("forever", 3, 0)
+
+			clock.Advance(time.Second)
+			_, ok := c.Get("short")
+			assert.False(t, ok)
+			assert.True(t, c.Contains("default"))
+
+			clock.Advance(time.Hour)
+			_, ok = c.Get("default")
+			assert.False(t, ok)
+			got, ok := c.Get("forever")
+			assert.True(t, ok)
+			assert.Equal(t, 3, got)
+
+			assert.Equal(t, []string{"short", "default"}, expired)
+			assert.Equal(t, uint64(2), c.Stats().Expirations)
+			assert.Equal(t, 1, c.Len())
+		})
+	}
+}
+
+func TestCache_Cost(t *testing.T) {
+	for name, newCache := range constructors {
+		t.Run(name, func(t *testing.T) {
+			var evicted []string
+			c, _ := newCache(10,
+				WithCost[string, int](func(value int) uint64 { return uint64(value) }),
+				WithOnEvict(func(key string, value int, reason EvictionReason) {
+					evicted = append(evicted, key)
+				}),
+			)
+			assert.ErrorIs(t, c.Put("huge", 11), ErrCostExceedsCapacity)
+			assert.NoError(t, c.Put("a", 4))
+			assert.NoError(t, c.Put("b", 4))
+			assert.Equal(t, uint64(8), c.Cost())
+			assert.NoError(t, c.Put("c", 4))
+			assert.Equal(t, []string{"a"}, evicted)
+			assert.Equal(t, uint64(8), c.Cost())
+
+			assert.NoError(t, c.Put("c", 9))
+			assert.Equal(t, []string{"a", "b"}, evicted)
+			assert.Equal(t, uint64(9), c.Cost())
+			assert.Equal(t, 1, c.Len())
+		})
+	}
+}
+
+func TestCache_PurgeCallback(t *testing.T) {
+	for name, newCache := range constructors {
+		t.Run(name, func(t *testing.T) {
+			var c *Cache[string, int]
+			got := make(map[string]int)
+			c, _ = newCache(3, WithOnEvict(func(key string, value int, reason EvictionReason) {
+				assert.Equal(t, EvictedByPurge, reason)
+				assert.False(t, c.Contains(key))
+				got[key] = value
+			}))
+			_ = c.Put("a", 1)
+			_ = c.Put("b", 2)
+			c.Purge()
+			assert.Equal(t, map[string]int{"a": 1, "b": 2}, got)
+			assert.Zero(t, c.Len())
+			assert.Zero(t, c.Stats().Evictions, "purge isn't an eviction by capacity")
+		})
+	}
+}
+
+func TestCache_EvictCallbackReentrant(t *testing.T) {
+	for name, newCache := range constructors {
+		t.Run(name, func(t *testing.T) {
+			var c *Cache[string, int]
+			c, _ = newCache(1, WithOnEvict(func(key string, value int, reason EvictionReason) {
+				assert.False(t, c.Contains(key))
+			}))
+			_ = c.Put("a", 1)
+			_ = c.Put("b", 2)
+			assert.Equal(t, uint64(1), c.Stats().Evictions)
+		})
+	}
+}
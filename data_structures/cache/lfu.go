@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"algoritms_and_structures/data_structures/lists"
+)
+
+// lfu groups entries by access frequency, inside a group the least recent is evicted first
+type lfu[K comparable, V any] struct {
+	freqs   *hash_table.HashTable[uint64, *lists.DoubleLinkedList[*entry[K, V]]]
+	minFreq uint64
+}
+
+func (p *lfu[K, V]) bucket(freq uint64) *lists.DoubleLinkedList[*entry[K, V]] {
+	bucket, ok := p.freqs.Lookup(freq)
+	if !ok {
+		bucket = lists.NewDoubleLinkedList[*entry[K, V]]()
+		_ = p.freqs.Put(freq, bucket)
+	}
+	return bucket
+}
+
+func (p *lfu[K, V]) unlink(e *entry[K, V]) {
+	bucket, ok := p.freqs.Lookup(e.freq)
+	if !ok {
+		return
+	}
+	_ = bucket.Unlink(e.node)
+	if bucket.Length() < 1 {
+		_ = p.freqs.Remove(e.freq)
+	}
+}
+
+// add keeps the frequency of a re-added entry
+func (p *lfu[K, V]) add(e *entry[K, V]) {
+	if e.freq < 1 {
+		e.freq = 1
+	}
+	if p.minFreq < 1 || e.freq < p.minFreq {
+		p.minFreq = e.freq
+	}
+	_ = p.bucket(e.freq).LinkHead(e.node)
+}
+
+func (p *lfu[K, V]) touch(e *entry[K, V]) {
+	p.unlink(e)
+	if e.freq == p.minFreq {
+		if ok, _ := p.freqs.Contains(e.freq); !ok {
+			p.minFreq++
+		}
+	}
+	e.freq++
+	_ = p.bucket(e.freq).LinkHead(e.node)
+}
+
+func (p *lfu[K, V]) remove(e *entry[K, V]) {
+	p.unlink(e)
+}
+
+func (p *lfu[K, V]) victim() *entry[K, V] {
+	if p.freqs.IsEmpty() {
+		return nil
+	}
+	bucket, ok := p.freqs.Lookup(p.minFreq)
+	if !ok {
+		// the least frequent group was removed, find the next one
+		p.minFreq = 0
+		for freq := range p.freqs.KeysSeq() {
+			if p.minFreq == 0 || freq < p.minFreq {
+				p.minFreq = freq
+			}
+		}
+		bucket, _ = p.freqs.Lookup(p.minFreq)
+	}
+	return bucket.Tail().Value()
+}
+
+func NewLFU[K comparable, V any](capacity uint64, opts ...Option[K, V]) (*Cache[K, V], error) {
+	p := &lfu[K, V]{
+		freqs: hash_table.NewHashTable[uint64, *lists.DoubleLinkedList[*entry[K, V]]](
+			hash_table.WithHasher[uint64](hash_table.IntegerHasher[uint64]{}),
+		),
+	}
+	return newCache[K, V](capacity, p, opts)
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLFU_Eviction(t *testing.T) {
+	type testCase struct {
+		name    string
+		actions func(c *Cache[string, int])
+		want    []string
+	}
+	tests := []testCase{
+		{
+			name: "evict least frequent",
+			actions: func(c *Cache[string, int]) {
+				_ = c.Put("a", 1)
+				_ = c.Put("b", 2)
+				_ = c.Put("c", 3)
+				c.Get("a")
+				c.Get("a")
+				c.Get("b")
+				_ = c.Put("d", 4)
+			},
+			want: []string{"c"},
+		},
+		{
+			name: "ties are broken by recency",
+			actions: func(c *Cache[string, int]) {
+				_ = c.Put("a", 1)
+				_ = c.Put("b", 2)
+				_ = c.Put("c", 3)
+				_ = c.Put("d", 4)
+			},
+			want: []string{"a"},
+		},
+		{
+			name: "new entries are evicted before frequent ones",
+			actions: func(c *Cache[string, int]) {
+				_ = c.Put("a", 1)
+				_ = c.Put("b", 2)
+				_ = c.Put("c", 3)
+				for i := 0; i < 3; i++ {
+					c.Get("a")
+					c.Get("b")
+					c.Get("c")
+				}
+				_ = c.Put("d", 4)
+				_ = c.Put("e", 5)
+			},
+			want: []string{"a", "d"},
+		},
+		{
+			name: "update keeps frequency",
+			actions: func(c *Cache[string, int]) {
+				_ = c.Put("a", 1)
+				_ = c.Put("b", 2)
+				_ = c.Put("c", 3)
+				c.Get("b")
+				c.Get("c")
+				_ = c.Put("a", 10)
+				c.Get("a")
+				_ = c.Put("d", 4)
+			},
+			want: []string{"b"},
+		},
+		{
+			name: "min frequency is restored after remove",
+			actions: func(c *Cache[string, int]) {
+				_ = c.Put("a", 1)
+				_ = c.Put("b", 2)
+				_ = c.Put("c", 3)
+				c.Get("a")
+				c.Get("b")
+				c.Get("b")
+				c.Get("c")
+				c.Get("c")
+				c.Get("c")
+				c.Remove("a")
+				_ = c.Put("d", 4)
+				c.Get("d")
+				c.Get("d")
+				_ = c.Put("e", 5)
+			},
+			want: []string{"b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var evicted []string
+			c, _ := NewLFU[string, int](3, WithOnEvict(func(key string, value int, reason EvictionReason) {
+				evicted = append(evicted, key)
+			}))
+			tt.actions(c)
+			assert.Equal(t, tt.want, evicted)
+			assert.Equal(t, 3, c.Len())
+		})
+	}
+}
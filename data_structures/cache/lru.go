@@ -0,0 +1,31 @@
+package cache
+
+import "algoritms_and_structures/data_structures/lists"
+
+// lru keeps the most recently used entry at the head
+type lru[K comparable, V any] struct {
+	order *lists.DoubleLinkedList[*entry[K, V]]
+}
+
+func (p *lru[K, V]) add(e *entry[K, V]) {
+	_ = p.order.LinkHead(e.node)
+}
+
+func (p *lru[K, V]) touch(e *entry[K, V]) {
+	_ = p.order.MoveToHead(e.node)
+}
+
+func (p *lru[K, V]) remove(e *entry[K, V]) {
+	_ = p.order.Unlink(e.node)
+}
+
+func (p *lru[K, V]) victim() *entry[K, V] {
+	if tail := p.order.Tail(); tail != nil {
+		return tail.Value()
+	}
+	return nil
+}
+
+func NewLRU[K comparable, V any](capacity uint64, opts ...Option[K, V]) (*Cache[K, V], error) {
+	return newCache[K, V](capacity, &lru[K, V]{order: lists.NewDoubleLinkedList[*entry[K, V]]()}, opts)
+}
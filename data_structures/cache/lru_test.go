@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLRU_Eviction(t *testing.T) {
+	type testCase struct {
+		name    string
+		actions func(c *Cache[string, int])
+		want    []string
+	}
+	tests := []testCase{
+		{
+			name: "evict oldest",
+			actions: func(c *Cache[string, int]) {
+				_ = c.Put("a", 1)
+				_ = c.Put("b", 2)
+				_ = c.Put("c", 3)
+				_ = c.Put("d", 4)
+			},
+			want: []string{"a"},
+		},
+		{
+			name: "get refreshes recency",
+			actions: func(c *Cache[string, int]) {
+				_ = c.Put("a", 1)
+				_ = c.Put("b", 2)
+				_ = c.Put("c", 3)
+				c.Get("a")
+				_ = c.Put("d", 4)
+				_ = c.Put("e", 5)
+			},
+			want: []string{"b", "c"},
+		},
+		{
+			name: "put refreshes recency",
+			actions: func(c *Cache[string, int]) {
+				_ = c.Put("a", 1)
+				_ = c.Put("b", 2)
+				_ = c.Put("c", 3)
+				_ = c.Put("a", 10)
+				_ = c.Put("d", 4)
+			},
+			want: []string{"b"},
+		},
+		{
+			name: "peek keeps recency",
+			actions: func(c *Cache[string, int]) {
+				_ = c.Put("a", 1)
+				_ = c.Put("b", 2)
+				_ = c.Put("c", 3)
+				c.Peek("a")
+				_ = c.Put("d", 4)
+			},
+			want: []string{"a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var evicted []string
+			c, _ := NewLRU[string, int](3, WithOnEvict(func(key string, value int, reason EvictionReason) {
+				assert.Equal(t, EvictedByCapacity, reason)
+				evicted = append(evicted, key)
+			}))
+			tt.actions(c)
+			assert.Equal(t, tt.want, evicted)
+			assert.Equal(t, 3, c.Len())
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package consistent
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"errors"
+)
+
+const defaultReplicas = 100
+
+type options[K, N comparable] struct {
+	keyHasher  hash_table.Hasher[K]
+	nodeHasher hash_table.Hasher[N]
+	replicas   int
+}
+
+type Option[K, N comparable] func(o *options[K, N])
+
+func WithKeyHasher[K, N comparable](hasher hash_table.Hasher[K]) Option[K, N] {
+	return func(o *options[K, N]) {
+		if hasher != nil {
+			o.keyHasher = hasher
+		}
+	}
+}
+
+func WithNodeHasher[K, N comparable](hasher hash_table.Hasher[N]) Option[K, N] {
+	return func(o *options[K, N]) {
+		if hasher != nil {
+			o.nodeHasher = hasher
+		}
+	}
+}
+
+// WithReplicas sets virtual nodes per weight unit of a Ring node, rendezvous hashing ignores it
+func WithReplicas[K, N comparable](replicas int) Option[K, N] {
+	return func(o *options[K, N]) {
+		if replicas > 0 {
+			o.replicas = replicas
+		}
+	}
+}
+
+func newOptions[K, N comparable](opts []Option[K, N]) options[K, N] {
+	o := options[K, N]{
+		keyHasher:  hash_table.FNVHasher[K]{},
+		nodeHasher: hash_table.FNVHasher[N]{},
+		replicas:   defaultReplicas,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+var (
+	ErrWeightIsEmpty     = errors.New("node weight cannot be 0")
+	ErrNodeAlreadyExists = errors.New("node already exists")
+	ErrNodeIsNotFound    = errors.New("cannot find node")
+	ErrNodesIsEmpty      = errors.New("there are no nodes")
+)
@@ -0,0 +1,156 @@
+package consistent
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type placement interface {
+	AddNode(node string, weight int) error
+	RemoveNode(node string) error
+	Locate(key int) (string, error)
+	LocateN(key int, n int) ([]string, error)
+	Size() int
+	IsEmpty() bool
+	Nodes() []string
+}
+
+var placements = map[string]func(opts ...Option[int, string]) placement{
+	"ring":       func(opts ...Option[int, string]) placement { return NewRing[int, string](opts...) },
+	"rendezvous": func(opts ...Option[int, string]) placement { return NewRendezvous[int, string](opts...) },
+}
+
+const keys = 20000
+
+func fetchPlacement(t *testing.T, newPlacement func(opts ...Option[int, string]) placement, weights ...int) placement {
+	t.Helper()
+	p := newPlacement()
+	for i, w := range weights {
+		assert.NoError(t, p.AddNode(fmt.Sprintf("node-%d", i), w))
+	}
+	return p
+}
+
+func locateAll(t *testing.T, p placement) map[int]string {
+	t.Helper()
+	owners := make(map[int]string, keys)
+	for key := 0; key < keys; key++ {
+		node, err := p.Locate(key)
+		assert.NoError(t, err)
+		owners[key] = node
+	}
+	return owners
+}
+
+func TestPlacement_Nodes(t *testing.T) {
+	for name, newPlacement := range placements {
+		t.Run(name, func(t *testing.T) {
+			p := newPlacement()
+			assert.True(t, p.IsEmpty())
+			_, err := p.Locate(1)
+			assert.ErrorIs(t, err, ErrNodesIsEmpty)
+			_, err = p.LocateN(1, 2)
+			assert.ErrorIs(t, err, ErrNodesIsEmpty)
+
+			assert.ErrorIs(t, p.AddNode("a", 0), ErrWeightIsEmpty)
+			assert.NoError(t, p.AddNode("a", 1))
+			assert.NoError(t, p.AddNode("b", 1))
+			assert.ErrorIs(t, p.AddNode("a", 2), ErrNodeAlreadyExists)
+			assert.Equal(t, 2, p.Size())
+			assert.ElementsMatch(t, []string{"a", "b"}, p.Nodes())
+
+			assert.NoError(t, p.RemoveNode("a"))
+			assert.ErrorIs(t, p.RemoveNode("a"), ErrNodeIsNotFound)
+			got, err := p.Locate(1)
+			assert.NoError(t, err)
+			assert.Equal(t, "b", got)
+		})
+	}
+}
+
+func TestPlacement_Balance(t *testing.T) {
+	for name, newPlacement := range placements {
+		t.Run(name, func(t *testing.T) {
+			p := fetchPlacement(t, newPlacement, 1, 1, 1, 1, 2)
+			counts := make(map[string]int)
+			for _, node := range locateAll(t, p) {
+				counts[node]++
+			}
+			for i := 0; i < 4; i++ {
+				assert.InEpsilon(t, keys/6, counts[fmt.Sprintf("node-%d", i)], 0.25)
+			}
+			assert.InEpsilon(t, keys/3, counts["node-4"], 0.25, "weight 2 must get twice as many keys")
+		})
+	}
+}
+
+func TestPlacement_MinimalMoves(t *testing.T) {
+	for name, newPlacement := range placements {
+		t.Run(name, func(t *testing.T) {
+			p := fetchPlacement(t, newPlacement, 1, 1, 1, 1)
+			before := locateAll(t, p)
+
+			assert.NoError(t, p.AddNode("new", 1))
+			after := locateAll(t, p)
+			var moved int
+			for key, node := range after {
+				if node != before[key] {
+					moved++
+					assert.Equal(t, "new", node, "keys may only move to the new node")
+				}
+			}
+			assert.InEpsilon(t, keys/5, moved, 0.25)
+
+			assert.NoError(t, p.RemoveNode("new"))
+			assert.Equal(t, before, locateAll(t, p), "removing the node must restore the placement")
+		})
+	}
+}
+
+func TestPlacement_LocateN(t *testing.T) {
+	for name, newPlacement := range placements {
+		t.Run(name, func(t *testing.T) {
+			p := fetchPlacement(t, newPlacement, 1, 1, 1, 1)
+			for key := 0; key < 100; key++ {
+				nodes, err := p.LocateN(key, 3)
+				assert.NoError(t, err)
+				assert.Len(t, nodes, 3)
+				assert.Len(t, map[string]bool{nodes[0]: true, nodes[1]: true, nodes[2]: true}, 3)
+				first, _ := p.Locate(key)
+				assert.Equal(t, first, nodes[0])
+			}
+			nodes, _ := p.LocateN(1, 10)
+			assert.Len(t, nodes, 4)
+			nodes, _ = p.LocateN(1, -1)
+			assert.Empty(t, nodes)
+		})
+	}
+}
+
+func TestPlacement_HasherError(t *testing.T) {
+	keyHasher := hash_table.MockHasher[int]{}
+	keyHasher.On("Hash", mock.AnythingOfType("int")).
+		Return(uint64(0), errors.New("test err"))
+	nodeHasher := hash_table.MockHasher[string]{}
+	nodeHasher.On("Hash", mock.AnythingOfType("string")).
+		Return(uint64(0), errors.New("test err"))
+
+	for name, newPlacement := range placements {
+		t.Run(name, func(t *testing.T) {
+			p := newPlacement(WithKeyHasher[int, string](&keyHasher))
+			assert.NoError(t, p.AddNode("a", 1))
+			_, err := p.Locate(1)
+			assert.Error(t, err)
+			_, err = p.LocateN(1, 1)
+			assert.Error(t, err)
+
+			p = newPlacement(WithNodeHasher[int, string](&nodeHasher))
+			assert.Error(t, p.AddNode("a", 1))
+			assert.True(t, p.IsEmpty())
+		})
+	}
+}
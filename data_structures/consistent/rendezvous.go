@@ -0,0 +1,136 @@
+package consistent
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"cmp"
+	"math"
+	"slices"
+	"sync"
+)
+
+type weightedNode[N comparable] struct {
+	node   N
+	hash   uint64
+	weight int
+}
+
+type score[N comparable] struct {
+	node  N
+	score float64
+}
+
+// Rendezvous gives a key to the node with the highest weighted score of the pair,
+// it needs no virtual nodes but Locate costs O(nodes)
+type Rendezvous[K, N comparable] struct {
+	mu    sync.RWMutex
+	opts  options[K, N]
+	nodes []weightedNode[N]
+}
+
+func (r *Rendezvous[K, N]) Size() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.nodes)
+}
+
+func (r *Rendezvous[K, N]) IsEmpty() bool {
+	return r.Size() < 1
+}
+
+func (r *Rendezvous[K, N]) Nodes() []N {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	nodes := make([]N, 0, len(r.nodes))
+	for _, n := range r.nodes {
+		nodes = append(nodes, n.node)
+	}
+	return nodes
+}
+
+func (r *Rendezvous[K, N]) find(node N) int {
+	return slices.IndexFunc(r.nodes, func(n weightedNode[N]) bool {
+		return n.node == node
+	})
+}
+
+func (r *Rendezvous[K, N]) AddNode(node N, weight int) error {
+	if weight < 1 {
+		return ErrWeightIsEmpty
+	}
+	h, err := r.opts.nodeHasher.Hash(node)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.find(node) >= 0 {
+		return ErrNodeAlreadyExists
+	}
+	r.nodes = append(r.nodes, weightedNode[N]{node: node, hash: hash_table.Mix(h), weight: weight})
+	return nil
+}
+
+func (r *Rendezvous[K, N]) RemoveNode(node N) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.find(node)
+	if i < 0 {
+		return ErrNodeIsNotFound
+	}
+	r.nodes = slices.Delete(r.nodes, i, i+1)
+	return nil
+}
+
+// scores uses -weight/ln(u) with u uniform in (0, 1),
+// so every node wins a share of keys proportional to its weight
+func (r *Rendezvous[K, N]) scores(key K) ([]score[N], error) {
+	h, err := r.opts.keyHasher.Hash(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.nodes) == 0 {
+		return nil, ErrNodesIsEmpty
+	}
+	scores := make([]score[N], len(r.nodes))
+	for i, n := range r.nodes {
+		u := (float64(hash_table.Mix(h^n.hash)>>11) + 0.5) / (1 << 53)
+		scores[i] = score[N]{node: n.node, score: -float64(n.weight) / math.Log(u)}
+	}
+	return scores, nil
+}
+
+func (r *Rendezvous[K, N]) Locate(key K) (N, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	scores, err := r.scores(key)
+	if err != nil {
+		return *new(N), err
+	}
+	best := slices.MaxFunc(scores, func(a, b score[N]) int {
+		return cmp.Compare(a.score, b.score)
+	})
+	return best.node, nil
+}
+
+// LocateN returns up to n nodes with the highest scores, the first one is Locate(key)
+func (r *Rendezvous[K, N]) LocateN(key K, n int) ([]N, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	scores, err := r.scores(key)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(scores, func(a, b score[N]) int {
+		return cmp.Compare(b.score, a.score)
+	})
+	n = min(max(n, 0), len(scores))
+	nodes := make([]N, 0, n)
+	for _, s := range scores[:n] {
+		nodes = append(nodes, s.node)
+	}
+	return nodes, nil
+}
+
+func NewRendezvous[K, N comparable](opts ...Option[K, N]) *Rendezvous[K, N] {
+	return &Rendezvous[K, N]{opts: newOptions(opts)}
+}
@@ -0,0 +1,146 @@
+package consistent
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+)
+
+type point[N comparable] struct {
+	hash uint64
+	node N
+}
+
+// Ring places every node at weight*replicas points of a hash circle,
+// a key belongs to the first point clockwise from its hash,
+// so adding or removing a node moves only the keys of its points
+type Ring[K, N comparable] struct {
+	mu      sync.RWMutex
+	opts    options[K, N]
+	points  []point[N]
+	weights map[N]int
+}
+
+func (r *Ring[K, N]) Size() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.weights)
+}
+
+func (r *Ring[K, N]) IsEmpty() bool {
+	return r.Size() < 1
+}
+
+func (r *Ring[K, N]) Nodes() []N {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	nodes := make([]N, 0, len(r.weights))
+	for node := range r.weights {
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+func (r *Ring[K, N]) Weight(node N) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	w, ok := r.weights[node]
+	return w, ok
+}
+
+// maxNodePoints bounds weight*replicas, so a single node can't allocate without limit
+const maxNodePoints = 1 << 20
+
+var ErrWeightIsTooLarge = errors.New("node weight times replicas cannot exceed 2^20")
+
+func (r *Ring[K, N]) AddNode(node N, weight int) error {
+	if weight < 1 {
+		return ErrWeightIsEmpty
+	}
+	if weight > maxNodePoints/r.opts.replicas {
+		return ErrWeightIsTooLarge
+	}
+	h, err := r.opts.nodeHasher.Hash(node)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.weights[node]; ok {
+		return ErrNodeAlreadyExists
+	}
+	r.weights[node] = weight
+	// the hash is mixed before adding i, see hash_table.Mix
+	h = hash_table.Mix(h)
+	for i := 0; i < weight*r.opts.replicas; i++ {
+		r.points = append(r.points, point[N]{hash: hash_table.Mix(h + uint64(i)), node: node})
+	}
+	sort.Slice(r.points, func(i, j int) bool {
+		return r.points[i].hash < r.points[j].hash
+	})
+	return nil
+}
+
+func (r *Ring[K, N]) RemoveNode(node N) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.weights[node]; !ok {
+		return ErrNodeIsNotFound
+	}
+	delete(r.weights, node)
+	r.points = slices.DeleteFunc(r.points, func(p point[N]) bool {
+		return p.node == node
+	})
+	return nil
+}
+
+// search returns the index of the first point clockwise from the key hash
+func (r *Ring[K, N]) search(key K) (int, error) {
+	h, err := r.opts.keyHasher.Hash(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(r.points) == 0 {
+		return 0, ErrNodesIsEmpty
+	}
+	h = hash_table.Mix(h)
+	i := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i].hash >= h
+	})
+	return i % len(r.points), nil
+}
+
+func (r *Ring[K, N]) Locate(key K) (N, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i, err := r.search(key)
+	if err != nil {
+		return *new(N), err
+	}
+	return r.points[i].node, nil
+}
+
+// LocateN returns up to n distinct nodes for replicas of key, the first one is Locate(key)
+func (r *Ring[K, N]) LocateN(key K, n int) ([]N, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i, err := r.search(key)
+	if err != nil {
+		return nil, err
+	}
+	n = max(min(n, len(r.weights)), 0)
+	nodes := make([]N, 0, n)
+	for j := 0; len(nodes) < n; j++ {
+		node := r.points[(i+j)%len(r.points)].node
+		if !slices.Contains(nodes, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes, nil
+}
+
+func NewRing[K, N comparable](opts ...Option[K, N]) *Ring[K, N] {
+	return &Ring[K, N]{opts: newOptions(opts), weights: make(map[N]int)}
+}
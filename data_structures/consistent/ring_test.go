@@ -0,0 +1,56 @@
+package consistent
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"sort"
+	"testing"
+)
+
+func TestRing_Points(t *testing.T) {
+	r := NewRing[string, string](WithReplicas[string, string](10), WithReplicas[string, string](0))
+	assert.NoError(t, r.AddNode("a", 1))
+	assert.NoError(t, r.AddNode("b", 3))
+	assert.Len(t, r.points, 40)
+	assert.True(t, sort.SliceIsSorted(r.points, func(i, j int) bool {
+		return r.points[i].hash < r.points[j].hash
+	}))
+	w, ok := r.Weight("b")
+	assert.True(t, ok)
+	assert.Equal(t, 3, w)
+
+	assert.NoError(t, r.RemoveNode("b"))
+	assert.Len(t, r.points, 10)
+	_, ok = r.Weight("b")
+	assert.False(t, ok)
+}
+
+func TestRing_WeightIsTooLarge(t *testing.T) {
+	r := NewRing[string, string]()
+	assert.ErrorIs(t, r.AddNode("a", maxNodePoints/defaultReplicas+1), ErrWeightIsTooLarge)
+	assert.ErrorIs(t, r.AddNode("a", math.MaxInt), ErrWeightIsTooLarge)
+	assert.True(t, r.IsEmpty())
+	assert.NoError(t, r.AddNode("a", maxNodePoints/defaultReplicas))
+	assert.LessOrEqual(t, len(r.points), maxNodePoints)
+}
+
+func TestRing_Wraps(t *testing.T) {
+	r := NewRing[uint64, string](WithKeyHasher[uint64, string](identityHasher{}), WithReplicas[uint64, string](1))
+	assert.NoError(t, r.AddNode("a", 1))
+	assert.NoError(t, r.AddNode("b", 1))
+	last := r.points[len(r.points)-1]
+	// keys after the last point belong to the first one
+	for key := uint64(0); key < 100; key++ {
+		node, _ := r.Locate(key)
+		if hash_table.Mix(key) > last.hash {
+			assert.Equal(t, r.points[0].node, node)
+		}
+	}
+}
+
+type identityHasher struct{}
+
+func (identityHasher) Hash(key uint64) (uint64, error) {
+	return key, nil
+}
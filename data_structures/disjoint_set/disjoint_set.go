@@ -0,0 +1,102 @@
+package disjoint_set
+
+import "errors"
+
+// DisjointSet tracks a partition of arbitrary comparable elements,
+// every element gets an index in an IntDisjointSet in the order it was added
+type DisjointSet[T comparable] struct {
+	index    map[T]int
+	elements []T
+	sets     IntDisjointSet
+}
+
+func (d *DisjointSet[T]) Size() int {
+	return len(d.elements)
+}
+
+func (d *DisjointSet[T]) IsEmpty() bool {
+	return len(d.elements) == 0
+}
+
+// Count returns the number of components
+func (d *DisjointSet[T]) Count() int {
+	return d.sets.Count()
+}
+
+func (d *DisjointSet[T]) Contains(x T) bool {
+	_, ok := d.index[x]
+	return ok
+}
+
+// Add makes x a singleton component, it reports false if x is already known
+func (d *DisjointSet[T]) Add(x T) bool {
+	if _, ok := d.index[x]; ok {
+		return false
+	}
+	d.index[x] = d.sets.Add()
+	d.elements = append(d.elements, x)
+	return true
+}
+
+func (d *DisjointSet[T]) indexOf(x T) int {
+	if i, ok := d.index[x]; ok {
+		return i
+	}
+	d.Add(x)
+	return len(d.elements) - 1
+}
+
+var ErrElementIsNotFound = errors.New("element is not found")
+
+// Find returns the representative of the component of x
+func (d *DisjointSet[T]) Find(x T) (T, error) {
+	i, ok := d.index[x]
+	if !ok {
+		return *new(T), ErrElementIsNotFound
+	}
+	return d.elements[d.sets.find(i)], nil
+}
+
+// Union merges the components of x and y and adds unknown elements first,
+// it reports whether they were different
+func (d *DisjointSet[T]) Union(x, y T) bool {
+	return d.sets.union(d.indexOf(x), d.indexOf(y))
+}
+
+// Connected reports whether x and y are in the same component, unknown elements never are
+func (d *DisjointSet[T]) Connected(x, y T) bool {
+	i, ok := d.index[x]
+	j, ok2 := d.index[y]
+	return ok && ok2 && d.sets.find(i) == d.sets.find(j)
+}
+
+// SetSize returns the size of the component of x
+func (d *DisjointSet[T]) SetSize(x T) (int, error) {
+	i, ok := d.index[x]
+	if !ok {
+		return 0, ErrElementIsNotFound
+	}
+	return d.sets.SetSize(i)
+}
+
+// Groups returns the components in the order of their first added element,
+// elements of a component keep their order of addition
+func (d *DisjointSet[T]) Groups() [][]T {
+	groups := make([][]T, 0, d.Count())
+	for _, indices := range d.sets.Groups() {
+		group := make([]T, len(indices))
+		for i, j := range indices {
+			group[i] = d.elements[j]
+		}
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+func NewDisjointSet[T comparable](elements ...T) *DisjointSet[T] {
+	d := &DisjointSet[T]{index: make(map[T]int, len(elements))}
+	for _, x := range elements {
+		d.Add(x)
+	}
+	return d
+}
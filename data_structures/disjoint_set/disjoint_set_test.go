@@ -0,0 +1,58 @@
+package disjoint_set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDisjointSet(t *testing.T) {
+	d := NewDisjointSet("a", "b", "c")
+	assert.Equal(t, 3, d.Size())
+	assert.False(t, d.Add("a"))
+	assert.True(t, d.Union("a", "b"))
+	assert.False(t, d.Union("b", "a"))
+	assert.True(t, d.Union("d", "e"), "unknown elements must be added")
+	assert.True(t, d.Contains("e"))
+	assert.Equal(t, 5, d.Size())
+	assert.Equal(t, 3, d.Count())
+
+	assert.True(t, d.Connected("a", "b"))
+	assert.False(t, d.Connected("a", "c"))
+	assert.False(t, d.Connected("a", "x"))
+	assert.False(t, d.Connected("x", "x"))
+
+	ra, _ := d.Find("a")
+	rb, err := d.Find("b")
+	assert.NoError(t, err)
+	assert.Equal(t, ra, rb)
+	_, err = d.Find("x")
+	assert.ErrorIs(t, err, ErrElementIsNotFound)
+
+	size, err := d.SetSize("e")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, size)
+	_, err = d.SetSize("x")
+	assert.ErrorIs(t, err, ErrElementIsNotFound)
+
+	assert.Equal(t, [][]string{{"a", "b"}, {"c"}, {"d", "e"}}, d.Groups())
+}
+
+func TestDisjointSet_Clusters(t *testing.T) {
+	type record struct {
+		id    int
+		email string
+	}
+	records := []record{{1, "x@a"}, {2, "y@a"}, {3, "x@a"}, {4, "z@a"}, {5, "y@a"}}
+	d := NewDisjointSet[int]()
+	assert.True(t, d.IsEmpty())
+	byEmail := make(map[string]int)
+	for _, r := range records {
+		d.Add(r.id)
+		if first, ok := byEmail[r.email]; ok {
+			d.Union(first, r.id)
+			continue
+		}
+		byEmail[r.email] = r.id
+	}
+	assert.Equal(t, [][]int{{1, 3}, {2, 5}, {4}}, d.Groups())
+}
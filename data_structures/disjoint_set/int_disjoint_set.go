@@ -0,0 +1,118 @@
+package disjoint_set
+
+import "errors"
+
+// IntDisjointSet keeps elements 0..n-1 in slices, it is the fast variant for dense integers.
+// Union links the smaller tree under the larger one and Find compresses the path,
+// so operations take amortized nearly constant time
+type IntDisjointSet struct {
+	parent []int
+	// size is valid for roots only
+	size  []int
+	count int
+}
+
+func (d *IntDisjointSet) valid(x int) bool {
+	return x >= 0 && x < len(d.parent)
+}
+
+// Len returns the number of elements
+func (d *IntDisjointSet) Len() int {
+	return len(d.parent)
+}
+
+// Count returns the number of components
+func (d *IntDisjointSet) Count() int {
+	return d.count
+}
+
+// Add makes a new singleton element and returns its index
+func (d *IntDisjointSet) Add() int {
+	d.parent = append(d.parent, len(d.parent))
+	d.size = append(d.size, 1)
+	d.count++
+	return len(d.parent) - 1
+}
+
+func (d *IntDisjointSet) find(x int) int {
+	root := x
+	for d.parent[root] != root {
+		root = d.parent[root]
+	}
+	for d.parent[x] != root {
+		d.parent[x], x = root, d.parent[x]
+	}
+	return root
+}
+
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+// Find returns the representative of the component of x
+func (d *IntDisjointSet) Find(x int) (int, error) {
+	if !d.valid(x) {
+		return 0, ErrIndexOutOfRange
+	}
+	return d.find(x), nil
+}
+
+// Union merges the components of x and y, it reports whether they were different
+func (d *IntDisjointSet) Union(x, y int) (bool, error) {
+	if !d.valid(x) || !d.valid(y) {
+		return false, ErrIndexOutOfRange
+	}
+	return d.union(x, y), nil
+}
+
+func (d *IntDisjointSet) union(x, y int) bool {
+	x, y = d.find(x), d.find(y)
+	if x == y {
+		return false
+	}
+	if d.size[x] < d.size[y] {
+		x, y = y, x
+	}
+	d.parent[y] = x
+	d.size[x] += d.size[y]
+	d.count--
+	return true
+}
+
+// Connected reports whether x and y are in the same component, out of range elements never are
+func (d *IntDisjointSet) Connected(x, y int) bool {
+	return d.valid(x) && d.valid(y) && d.find(x) == d.find(y)
+}
+
+// SetSize returns the size of the component of x
+func (d *IntDisjointSet) SetSize(x int) (int, error) {
+	if !d.valid(x) {
+		return 0, ErrIndexOutOfRange
+	}
+	return d.size[d.find(x)], nil
+}
+
+// Groups returns the components ordered by their smallest element, each in ascending order
+func (d *IntDisjointSet) Groups() [][]int {
+	groups := make([][]int, 0, d.count)
+	index := make(map[int]int, d.count)
+	for x := range d.parent {
+		root := d.find(x)
+		i, ok := index[root]
+		if !ok {
+			i = len(groups)
+			index[root] = i
+			groups = append(groups, make([]int, 0, d.size[root]))
+		}
+		groups[i] = append(groups[i], x)
+	}
+	return groups
+}
+
+func NewIntDisjointSet(n int) *IntDisjointSet {
+	n = max(n, 0)
+	d := &IntDisjointSet{parent: make([]int, n), size: make([]int, n), count: n}
+	for i := range d.parent {
+		d.parent[i] = i
+		d.size[i] = 1
+	}
+	return d
+}
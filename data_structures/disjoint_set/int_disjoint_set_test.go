@@ -0,0 +1,108 @@
+package disjoint_set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestIntDisjointSet_Union(t *testing.T) {
+	d := NewIntDisjointSet(6)
+	assert.Equal(t, 6, d.Len())
+	assert.Equal(t, 6, d.Count())
+
+	tests := []struct {
+		name string
+		x, y int
+		want bool
+	}{
+		{name: "different components", x: 0, y: 1, want: true},
+		{name: "joins a third", x: 1, y: 2, want: true},
+		{name: "same component", x: 2, y: 0, want: false},
+		{name: "another pair", x: 4, y: 5, want: true},
+		{name: "self", x: 3, y: 3, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.Union(tt.x, tt.y)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.Equal(t, 3, d.Count())
+	assert.True(t, d.Connected(0, 2))
+	assert.False(t, d.Connected(0, 4))
+	assert.False(t, d.Connected(0, 6))
+	size, _ := d.SetSize(1)
+	assert.Equal(t, 3, size)
+	assert.Equal(t, [][]int{{0, 1, 2}, {3}, {4, 5}}, d.Groups())
+
+	x := d.Add()
+	assert.Equal(t, 6, x)
+	assert.Equal(t, 4, d.Count())
+	root, _ := d.Find(x)
+	assert.Equal(t, x, root)
+}
+
+func TestIntDisjointSet_OutOfRange(t *testing.T) {
+	d := NewIntDisjointSet(2)
+	for _, x := range []int{-1, 2} {
+		_, err := d.Find(x)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+		_, err = d.Union(0, x)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+		_, err = d.SetSize(x)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+	}
+	assert.Equal(t, 0, NewIntDisjointSet(-1).Len())
+	var zero IntDisjointSet
+	zero.Add()
+	assert.Equal(t, 1, zero.Count())
+}
+
+func TestIntDisjointSet_PathCompression(t *testing.T) {
+	d := NewIntDisjointSet(4)
+	// a chain which union by size never builds, so Find has to flatten it
+	d.parent = []int{1, 2, 3, 3}
+	d.size[3] = 4
+	d.count = 1
+	root, _ := d.Find(0)
+	assert.Equal(t, 3, root)
+	assert.Equal(t, []int{3, 3, 3, 3}, d.parent)
+}
+
+func TestIntDisjointSet_Random(t *testing.T) {
+	const n = 1000
+	rnd := rand.New(rand.NewSource(1))
+	d := NewIntDisjointSet(n)
+	// naive labels relabel a whole component on every union
+	label := make([]int, n)
+	for i := range label {
+		label[i] = i
+	}
+	components := n
+	for i := 0; i < 2000; i++ {
+		x, y := rnd.Intn(n), rnd.Intn(n)
+		merged, _ := d.Union(x, y)
+		assert.Equal(t, label[x] != label[y], merged)
+		if old := label[y]; old != label[x] {
+			components--
+			for j := range label {
+				if label[j] == old {
+					label[j] = label[x]
+				}
+			}
+		}
+		a, b := rnd.Intn(n), rnd.Intn(n)
+		assert.Equal(t, label[a] == label[b], d.Connected(a, b))
+	}
+	assert.Equal(t, components, d.Count())
+	assert.Len(t, d.Groups(), components)
+	for _, group := range d.Groups() {
+		size, _ := d.SetSize(group[0])
+		assert.Len(t, group, size)
+		for _, x := range group {
+			assert.Equal(t, label[group[0]], label[x])
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package hash_table
+
+import (
+	"errors"
+	"iter"
+)
+
+var ErrValueAlreadyExists = errors.New("value is already bound to another key")
+
+// BiMap keeps keys and values unique, so it can be looked up from both sides
+type BiMap[K comparable, V comparable] struct {
+	forward  *HashTable[K, V]
+	backward *HashTable[V, K]
+	inverse  *BiMap[V, K]
+}
+
+func (b *BiMap[K, V]) Size() uint64 {
+	return b.forward.Size()
+}
+
+func (b *BiMap[K, V]) IsEmpty() bool {
+	return b.forward.IsEmpty()
+}
+
+// Inverse shares the storage, changes made through it are visible in b
+func (b *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	return b.inverse
+}
+
+func (b *BiMap[K, V]) Get(key K) (V, error) {
+	return b.forward.Get(key)
+}
+
+func (b *BiMap[K, V]) GetKey(value V) (K, error) {
+	return b.backward.Get(value)
+}
+
+func (b *BiMap[K, V]) Lookup(key K) (V, bool) {
+	return b.forward.Lookup(key)
+}
+
+func (b *BiMap[K, V]) LookupKey(value V) (K, bool) {
+	return b.backward.Lookup(value)
+}
+
+func (b *BiMap[K, V]) Contains(key K) bool {
+	_, ok := b.forward.Lookup(key)
+	return ok
+}
+
+func (b *BiMap[K, V]) ContainsValue(value V) bool {
+	_, ok := b.backward.Lookup(value)
+	return ok
+}
+
+// Put fails with ErrValueAlreadyExists when value belongs to another key
+func (b *BiMap[K, V]) Put(key K, value V) error {
+	if owner, ok := b.backward.Lookup(value); ok && owner != key {
+		return ErrValueAlreadyExists
+	}
+	return b.put(key, value)
+}
+
+// ForcePut drops the entry which held value before binding it to key
+func (b *BiMap[K, V]) ForcePut(key K, value V) error {
+	if owner, ok := b.backward.Lookup(value); ok && owner != key {
+		if err := b.Remove(owner); err != nil {
+			return err
+		}
+	}
+	return b.put(key, value)
+}
+
+// put writes the reverse entry, then the forward one, then drops the stale reverse entry.
+// A step which fails without a change undoes the earlier ones, the undo hashes only keys
+// which were hashed just before. Grow and shrink errors come after the change and are returned as is
+func (b *BiMap[K, V]) put(key K, value V) error {
+	old, replaced := b.forward.Lookup(key)
+	replaced = replaced && old != value
+	added, err := b.backward.put(value, key)
+	if err != nil && !added {
+		return err
+	}
+	stored, forwardErr := b.forward.put(key, value)
+	if forwardErr != nil && !stored {
+		if added {
+			_, _ = b.backward.remove(value)
+		}
+		return forwardErr
+	}
+	if !replaced {
+		return errors.Join(err, forwardErr)
+	}
+	removed, staleErr := b.backward.remove(old)
+	if !removed {
+		_, _ = b.forward.put(key, old)
+		if added {
+			_, _ = b.backward.remove(value)
+		}
+		return staleErr
+	}
+	return errors.Join(err, forwardErr, staleErr)
+}
+
+// Remove drops the reverse entry first and puts it back when the forward one can't be removed
+func (b *BiMap[K, V]) Remove(key K) error {
+	value, err := b.forward.Get(key)
+	if err != nil {
+		return err
+	}
+	removed, err := b.backward.remove(value)
+	if !removed {
+		return err
+	}
+	removed, forwardErr := b.forward.remove(key)
+	if !removed {
+		_, _ = b.backward.put(value, key)
+		return forwardErr
+	}
+	return errors.Join(err, forwardErr)
+}
+
+func (b *BiMap[K, V]) RemoveValue(value V) error {
+	return b.inverse.Remove(value)
+}
+
+func (b *BiMap[K, V]) Keys() []K {
+	return b.forward.Keys()
+}
+
+func (b *BiMap[K, V]) Values() []V {
+	return b.backward.Keys()
+}
+
+func (b *BiMap[K, V]) All() iter.Seq2[K, V] {
+	return b.forward.All()
+}
+
+func NewBiMap[K comparable, V comparable]() *BiMap[K, V] {
+	return NewBiMapWithOptions[K, V](nil, nil)
+}
+
+// NewBiMapWithOptions takes options for each side, keyOpts for keys and valueOpts for values
+func NewBiMapWithOptions[K comparable, V comparable](keyOpts []Option[K], valueOpts []Option[V]) *BiMap[K, V] {
+	b := &BiMap[K, V]{
+		forward:  NewHashTable[K, V](keyOpts...),
+		backward: NewHashTable[V, K](valueOpts...),
+	}
+	b.inverse = &BiMap[V, K]{forward: b.backward, backward: b.forward, inverse: b}
+	return b
+}
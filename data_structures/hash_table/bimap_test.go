@@ -0,0 +1,170 @@
+package hash_table
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"maps"
+	"testing"
+)
+
+func fetchBiMap() *BiMap[string, int] {
+	b := NewBiMap[string, int]()
+	_ = b.Put("one", 1)
+	_ = b.Put("two", 2)
+	return b
+}
+
+func TestBiMap_Put(t *testing.T) {
+	type testCase struct {
+		name    string
+		key     string
+		value   int
+		wantErr error
+		want    map[string]int
+	}
+	tests := []testCase{
+		{name: "new entry", key: "three", value: 3, want: map[string]int{"one": 1, "two": 2, "three": 3}},
+		{name: "same entry", key: "one", value: 1, want: map[string]int{"one": 1, "two": 2}},
+		{name: "replace value", key: "one", value: 10, want: map[string]int{"one": 10, "two": 2}},
+		{name: "got error when value is taken", key: "three", value: 2, wantErr: ErrValueAlreadyExists, want: map[string]int{"one": 1, "two": 2}},
+		{name: "got error on swap", key: "one", value: 2, wantErr: ErrValueAlreadyExists, want: map[string]int{"one": 1, "two": 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := fetchBiMap()
+			assert.ErrorIs(t, b.Put(tt.key, tt.value), tt.wantErr)
+			assertBiMap(t, tt.want, b)
+		})
+	}
+}
+
+func assertBiMap(t *testing.T, want map[string]int, b *BiMap[string, int]) {
+	assert.Equal(t, want, maps.Collect(b.All()))
+	assert.Equal(t, uint64(len(want)), b.Size())
+	assert.Equal(t, uint64(len(want)), b.Inverse().Size())
+	for k, v := range want {
+		got, err := b.GetKey(v)
+		assert.NoError(t, err)
+		assert.Equal(t, k, got)
+	}
+}
+
+func TestBiMap_HasherError(t *testing.T) {
+	type testCase struct {
+		name    string
+		keys    func(h *MockHasher[string])
+		values  func(h *MockHasher[int])
+		actions func(b *BiMap[string, int]) error
+	}
+	errTest := errors.New("test err")
+	tests := []testCase{
+		{
+			name: "forward entry fails",
+			keys: func(h *MockHasher[string]) {
+				// "three" is looked up first, then fails when it is stored
+				h.On("Hash", "three").Return(uint64(0), nil).Once()
+				h.On("Hash", "three").Return(uint64(0), errTest).Once()
+			},
+			values:  func(h *MockHasher[int]) {},
+			actions: func(b *BiMap[string, int]) error { return b.Put("three", 3) },
+		},
+		{
+			name: "reverse entry fails",
+			keys: func(h *MockHasher[string]) {},
+			values: func(h *MockHasher[int]) {
+				// 3 is looked up by Put first, then fails when it is stored
+				h.On("Hash", 3).Return(uint64(0), nil).Once()
+				h.On("Hash", 3).Return(uint64(0), errTest).Once()
+			},
+			actions: func(b *BiMap[string, int]) error { return b.Put("three", 3) },
+		},
+		{
+			name: "stale reverse entry fails",
+			keys: func(h *MockHasher[string]) {},
+			values: func(h *MockHasher[int]) {
+				// 1 is hashed by Put("one", 1) twice, then fails when Put("one", 10) drops it
+				h.On("Hash", 1).Return(uint64(0), nil).Times(2)
+				h.On("Hash", 1).Return(uint64(0), errTest).Once()
+			},
+			actions: func(b *BiMap[string, int]) error { return b.Put("one", 10) },
+		},
+		{
+			name: "forward removal fails",
+			keys: func(h *MockHasher[string]) {
+				// "two" is hashed by Put("two", 2) twice and by Get in Remove, then fails when removed
+				h.On("Hash", "two").Return(uint64(0), nil).Times(3)
+				h.On("Hash", "two").Return(uint64(0), errTest).Once()
+			},
+			values:  func(h *MockHasher[int]) {},
+			actions: func(b *BiMap[string, int]) error { return b.ForcePut("three", 2) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, values := &MockHasher[string]{}, &MockHasher[int]{}
+			tt.keys(keys)
+			tt.values(values)
+			keys.On("Hash", mock.AnythingOfType("string")).Return(uint64(0), nil)
+			values.On("Hash", mock.AnythingOfType("int")).Return(uint64(0), nil)
+			b := NewBiMapWithOptions[string, int]([]Option[string]{WithHasher[string](keys)}, []Option[int]{WithHasher[int](values)})
+			_ = b.Put("one", 1)
+			_ = b.Put("two", 2)
+
+			assert.ErrorIs(t, tt.actions(b), errTest)
+			assertBiMap(t, map[string]int{"one": 1, "two": 2}, b)
+		})
+	}
+}
+
+func TestNewBiMapWithOptions(t *testing.T) {
+	b := NewBiMapWithOptions[string, int]([]Option[string]{WithHasher[string](StringHasher[string]{})}, []Option[int]{WithHasher[int](IntegerHasher[int]{})})
+	assert.IsType(t, StringHasher[string]{}, b.forward.hasher)
+	assert.IsType(t, IntegerHasher[int]{}, b.backward.hasher)
+	assert.IsType(t, FNVHasher[int]{}, NewBiMapWithOptions[int, int](nil, nil).backward.hasher)
+	assert.NoError(t, b.Put("one", 1))
+	assertBiMap(t, map[string]int{"one": 1}, b)
+}
+
+func TestBiMap_ForcePut(t *testing.T) {
+	b := fetchBiMap()
+	assert.NoError(t, b.ForcePut("three", 2))
+	assertBiMap(t, map[string]int{"one": 1, "three": 2}, b)
+
+	assert.NoError(t, b.ForcePut("one", 2))
+	assertBiMap(t, map[string]int{"one": 2}, b)
+}
+
+func TestBiMap_Remove(t *testing.T) {
+	b := fetchBiMap()
+	assert.NoError(t, b.Remove("one"))
+	assert.False(t, b.ContainsValue(1))
+	assert.ErrorIs(t, b.Remove("one"), ErrElementIsEmptyByKey)
+
+	assert.NoError(t, b.RemoveValue(2))
+	assert.False(t, b.Contains("two"))
+	assert.ErrorIs(t, b.RemoveValue(2), ErrElementIsEmptyByKey)
+	assert.True(t, b.IsEmpty())
+}
+
+func TestBiMap_Inverse(t *testing.T) {
+	b := fetchBiMap()
+	inv := b.Inverse()
+	assert.Same(t, b, inv.Inverse())
+
+	got, ok := inv.Lookup(2)
+	assert.True(t, ok)
+	assert.Equal(t, "two", got)
+
+	assert.NoError(t, inv.Put(3, "three"))
+	value, ok := b.Lookup("three")
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	key, ok := b.LookupKey(3)
+	assert.True(t, ok)
+	assert.Equal(t, "three", key)
+
+	assert.ErrorIs(t, inv.Put(4, "one"), ErrValueAlreadyExists)
+	assert.ElementsMatch(t, []string{"one", "two", "three"}, b.Keys())
+	assert.ElementsMatch(t, []int{1, 2, 3}, b.Values())
+}
@@ -0,0 +1,198 @@
+package hash_table
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const defaultShards uint64 = 32
+
+type shard[K comparable, T any] struct {
+	mu    sync.RWMutex
+	table *HashTable[K, T]
+}
+
+// ConcurrentHashTable splits keys over independently locked HashTable shards
+type ConcurrentHashTable[K comparable, T any] struct {
+	shards []*shard[K, T]
+	hasher Hasher[K]
+}
+
+// ComputeFunc gets the current value and whether it exists,
+// it returns the new value and false when the key must be removed
+type ComputeFunc[T any] func(value T, ok bool) (T, bool)
+
+func (c *ConcurrentHashTable[K, T]) shard(key K) (*shard[K, T], error) {
+	hashVal, err := c.hasher.Hash(key)
+	if err != nil {
+		return nil, err
+	}
+	// the mixed hash picks the shard, the raw one is left to the shard buckets
+	return c.shards[Mix(hashVal)%uint64(len(c.shards))], nil
+}
+
+func (c *ConcurrentHashTable[K, T]) Shards() int {
+	return len(c.shards)
+}
+
+func (c *ConcurrentHashTable[K, T]) Size() uint64 {
+	var size uint64
+	for _, s := range c.shards {
+		s.mu.RLock()
+		size += s.table.Size()
+		s.mu.RUnlock()
+	}
+	return size
+}
+
+func (c *ConcurrentHashTable[K, T]) IsEmpty() bool {
+	return c.Size() < 1
+}
+
+func (c *ConcurrentHashTable[K, T]) Get(key K) (T, error) {
+	s, err := c.shard(key)
+	if err != nil {
+		return *new(T), newKeyNotFoundError(key, err)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.table.Get(key)
+}
+
+func (c *ConcurrentHashTable[K, T]) Lookup(key K) (T, bool) {
+	s, err := c.shard(key)
+	if err != nil {
+		return *new(T), false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.table.Lookup(key)
+}
+
+func (c *ConcurrentHashTable[K, T]) Contains(key K) (bool, error) {
+	s, err := c.shard(key)
+	if err != nil {
+		return false, newKeyNotFoundError(key, err)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.table.Contains(key)
+}
+
+func (c *ConcurrentHashTable[K, T]) Put(key K, val T) error {
+	s, err := c.shard(key)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.table.Put(key, val)
+}
+
+func (c *ConcurrentHashTable[K, T]) Remove(key K) error {
+	s, err := c.shard(key)
+	if err != nil {
+		return newKeyNotFoundError(key, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.table.Remove(key)
+}
+
+// GetOrPut returns the existing value and true, or stores val and returns it with false
+func (c *ConcurrentHashTable[K, T]) GetOrPut(key K, val T) (T, bool, error) {
+	s, err := c.shard(key)
+	if err != nil {
+		return *new(T), false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := s.table.lookup(key)
+	if err != nil {
+		return *new(T), false, err
+	}
+	if n != nil {
+		return n.value, true, nil
+	}
+	return val, false, s.table.Put(key, val)
+}
+
+// Compute atomically replaces the value of key with the result of f
+func (c *ConcurrentHashTable[K, T]) Compute(key K, f ComputeFunc[T]) (T, error) {
+	s, err := c.shard(key)
+	if err != nil {
+		return *new(T), err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := s.table.lookup(key)
+	if err != nil {
+		return *new(T), err
+	}
+	var old T
+	if n != nil {
+		old = n.value
+	}
+	val, keep := f(old, n != nil)
+	if !keep {
+		if n != nil {
+			return *new(T), s.table.Remove(key)
+		}
+		return *new(T), nil
+	}
+	if n != nil {
+		n.value = val
+		return val, nil
+	}
+	return val, s.table.Put(key, val)
+}
+
+var ErrValueIsNotComparable = errors.New("value type is not comparable")
+
+// CompareAndSwap stores newVal only when key currently holds old.
+// Values are compared with ==, so T must be comparable at runtime
+func (c *ConcurrentHashTable[K, T]) CompareAndSwap(key K, old, newVal T) (swapped bool, err error) {
+	s, err := c.shard(key)
+	if err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, err := s.table.lookup(key)
+	if err != nil || n == nil {
+		return false, err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			swapped, err = false, fmt.Errorf("%w: %v", ErrValueIsNotComparable, r)
+		}
+	}()
+	if any(n.value) != any(old) {
+		return false, nil
+	}
+	n.value = newVal
+	return true, nil
+}
+
+var ErrShardsIsEmpty = errors.New("shards count cannot be 0")
+
+func NewConcurrentHashTable[K comparable, T any](opts ...Option[K]) *ConcurrentHashTable[K, T] {
+	c, _ := NewConcurrentHashTableWithShards[K, T](defaultShards, opts...)
+	return c
+}
+
+func NewConcurrentHashTableWithShards[K comparable, T any](shards uint64, opts ...Option[K]) (*ConcurrentHashTable[K, T], error) {
+	if shards < 1 {
+		return new(ConcurrentHashTable[K, T]), ErrShardsIsEmpty
+	}
+	o := newOptions(opts)
+	c := &ConcurrentHashTable[K, T]{
+		shards: make([]*shard[K, T], shards),
+		hasher: o.hasher,
+	}
+	for i := range c.shards {
+		c.shards[i] = &shard[K, T]{table: NewHashTable[K, T](WithHasher(o.hasher))}
+	}
+	return c, nil
+}
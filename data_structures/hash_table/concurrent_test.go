@@ -0,0 +1,210 @@
+package hash_table
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	workers    = 16
+	iterations = 1000
+)
+
+func TestNewConcurrentHashTableWithShards(t *testing.T) {
+	_, err := NewConcurrentHashTableWithShards[int, int](0)
+	assert.ErrorIs(t, err, ErrShardsIsEmpty)
+
+	c, err := NewConcurrentHashTableWithShards[int, int](4)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, c.Shards())
+	assert.True(t, c.IsEmpty())
+}
+
+func TestConcurrentHashTable_ShardSpread(t *testing.T) {
+	for _, format := range []string{"%d", "user-%d"} {
+		t.Run(format, func(t *testing.T) {
+			c := NewConcurrentHashTable[string, int]()
+			for i := 0; i < 1000; i++ {
+				assert.NoError(t, c.Put(fmt.Sprintf(format, i), i))
+			}
+			var used int
+			for _, s := range c.shards {
+				if s.table.Size() > 0 {
+					used++
+				}
+				assert.Less(t, s.table.Size(), uint64(100), "a shard got far more than 1000/32 keys")
+			}
+			assert.Greater(t, used, c.Shards()*3/4)
+		})
+	}
+}
+
+func TestConcurrentHashTable_Parallel(t *testing.T) {
+	c := NewConcurrentHashTable[string, int](WithHasher[string](StringHasher[string]{}))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				key := fmt.Sprintf("%d-%d", w, i)
+				assert.NoError(t, c.Put(key, i))
+				got, err := c.Get(key)
+				assert.NoError(t, err)
+				assert.Equal(t, i, got)
+				if i%2 == 0 {
+					assert.NoError(t, c.Remove(key))
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint64(workers*iterations/2), c.Size())
+	for w := 0; w < workers; w++ {
+		for i := 0; i < iterations; i++ {
+			ok, err := c.Contains(fmt.Sprintf("%d-%d", w, i))
+			assert.NoError(t, err)
+			assert.Equal(t, i%2 == 1, ok)
+		}
+	}
+}
+
+func TestConcurrentHashTable_GetOrPut(t *testing.T) {
+	c := NewConcurrentHashTable[int, int]()
+	var stored atomic.Int32
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				_, loaded, err := c.GetOrPut(i, w)
+				assert.NoError(t, err)
+				if !loaded {
+					stored.Add(1)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(iterations), stored.Load(), "every key must be stored exactly once")
+	got, loaded, err := c.GetOrPut(0, -1)
+	assert.NoError(t, err)
+	assert.True(t, loaded)
+	assert.NotEqual(t, -1, got)
+}
+
+func TestConcurrentHashTable_Compute(t *testing.T) {
+	c := NewConcurrentHashTable[string, int]()
+	increment := func(value int, ok bool) (int, bool) {
+		return value + 1, true
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				_, err := c.Compute("counter", increment)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := c.Get("counter")
+	assert.NoError(t, err)
+	assert.Equal(t, workers*iterations, got)
+
+	got, err = c.Compute("counter", func(value int, ok bool) (int, bool) {
+		return 0, false
+	})
+	assert.NoError(t, err)
+	assert.Zero(t, got)
+	ok, _ := c.Contains("counter")
+	assert.False(t, ok)
+
+	got, err = c.Compute("absent", func(value int, ok bool) (int, bool) {
+		return 0, false
+	})
+	assert.NoError(t, err)
+	assert.Zero(t, got)
+}
+
+func TestConcurrentHashTable_CompareAndSwap(t *testing.T) {
+	c := NewConcurrentHashTable[string, int]()
+	swapped, err := c.CompareAndSwap("counter", 0, 1)
+	assert.NoError(t, err)
+	assert.False(t, swapped, "absent key cannot be swapped")
+
+	assert.NoError(t, c.Put("counter", 0))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				for {
+					cur, err := c.Get("counter")
+					assert.NoError(t, err)
+					swapped, err := c.CompareAndSwap("counter", cur, cur+1)
+					assert.NoError(t, err)
+					if swapped {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, _ := c.Get("counter")
+	assert.Equal(t, workers*iterations, got)
+}
+
+func TestConcurrentHashTable_CompareAndSwapNotComparable(t *testing.T) {
+	c := NewConcurrentHashTable[int, any]()
+	assert.NoError(t, c.Put(1, []int{1}))
+	swapped, err := c.CompareAndSwap(1, []int{1}, []int{2})
+	assert.ErrorIs(t, err, ErrValueIsNotComparable)
+	assert.False(t, swapped)
+}
+
+func TestConcurrentHashTable_HasherError(t *testing.T) {
+	c := NewConcurrentHashTable[int, int]()
+	h := MockHasher[int]{}
+	h.On("Hash", mock.AnythingOfType("int")).
+		Return(uint64(0), errors.New("test err"))
+	c.hasher = &h
+
+	_, err := c.Get(1)
+	assert.Error(t, err)
+	_, err = c.Contains(1)
+	assert.Error(t, err)
+	assert.Error(t, c.Put(1, 1))
+	assert.Error(t, c.Remove(1))
+	_, _, err = c.GetOrPut(1, 1)
+	assert.Error(t, err)
+	_, err = c.Compute(1, func(value int, ok bool) (int, bool) { return value, true })
+	assert.Error(t, err)
+	_, err = c.CompareAndSwap(1, 0, 1)
+	assert.Error(t, err)
+}
+
+func TestConcurrentHashTable_Lookup(t *testing.T) {
+	c := NewConcurrentHashTable[int, int]()
+	_ = c.Put(1, 10)
+	got, ok := c.Lookup(1)
+	assert.True(t, ok)
+	assert.Equal(t, 10, got)
+	_, ok = c.Lookup(2)
+	assert.False(t, ok)
+	assert.ErrorIs(t, c.Remove(2), ErrElementIsEmptyByKey)
+}
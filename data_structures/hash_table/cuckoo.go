@@ -0,0 +1,302 @@
+package hash_table
+
+import "errors"
+
+const (
+	defaultCuckooFunctions        = 2
+	cuckooMaxLoadFactor           = 0.5
+	cuckooStashSize               = 4
+	cuckooMaxKicks                = 64
+	cuckooMaxRehashes             = 8
+	cuckooSeedStep         uint64 = 0x9e3779b97f4a7c15
+)
+
+type cuckooSlot[K comparable, T any] struct {
+	key   K
+	value T
+	hash  uint64
+	used  bool
+}
+
+// CuckooHashTable keeps every key in one of its functions candidate slots or in a small stash,
+// so Get checks at most functions+cuckooStashSize slots.
+// Each table derives its function from the hasher with its own seed, like SeededHasher,
+// a kick chain longer than cuckooMaxKicks is treated as a cycle and the tables are rehashed with new seeds.
+// Keys with equal hashes share their candidate slots, Put rejects the ones the stash cannot take
+type CuckooHashTable[K comparable, T any] struct {
+	size     uint64
+	capacity uint64
+	seed     uint64
+	seeds    []uint64
+	tables   [][]cuckooSlot[K, T]
+	stash    []cuckooSlot[K, T]
+	hasher   Hasher[K]
+}
+
+// Capacity returns the number of slots in all tables
+func (t *CuckooHashTable[K, T]) Capacity() uint64 {
+	return t.capacity * uint64(len(t.tables))
+}
+
+func (t *CuckooHashTable[K, T]) Size() uint64 {
+	return t.size
+}
+
+func (t *CuckooHashTable[K, T]) IsEmpty() bool {
+	return t.size < 1
+}
+
+func (t *CuckooHashTable[K, T]) Functions() int {
+	return len(t.tables)
+}
+
+func (t *CuckooHashTable[K, T]) index(table int, hash uint64) uint64 {
+	return Mix(hash^t.seeds[table]) & (t.capacity - 1)
+}
+
+func (t *CuckooHashTable[K, T]) reseed() {
+	for i := range t.seeds {
+		t.seed += cuckooSeedStep
+		t.seeds[i] = Mix(t.seed)
+	}
+}
+
+// find returns the slot holding key or nil
+func (t *CuckooHashTable[K, T]) find(key K) (*cuckooSlot[K, T], error) {
+	hash, err := t.hasher.Hash(key)
+	if err != nil {
+		return nil, err
+	}
+	for i := range t.tables {
+		s := &t.tables[i][t.index(i, hash)]
+		if s.used && s.hash == hash && s.key == key {
+			return s, nil
+		}
+	}
+	for i := range t.stash {
+		if t.stash[i].key == key {
+			return &t.stash[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (t *CuckooHashTable[K, T]) Get(key K) (T, error) {
+	s, err := t.find(key)
+	if err != nil || s == nil {
+		return *new(T), newKeyNotFoundError(key, err)
+	}
+	return s.value, nil
+}
+
+func (t *CuckooHashTable[K, T]) Lookup(key K) (T, bool) {
+	s, err := t.find(key)
+	if err != nil || s == nil {
+		return *new(T), false
+	}
+	return s.value, true
+}
+
+func (t *CuckooHashTable[K, T]) Contains(key K) (bool, error) {
+	s, err := t.find(key)
+	if err != nil {
+		return false, newKeyNotFoundError(key, err)
+	}
+	return s != nil, nil
+}
+
+func (t *CuckooHashTable[K, T]) Put(key K, val T) error {
+	s, err := t.find(key)
+	if err != nil {
+		return err
+	}
+	if s != nil {
+		s.value = val
+		return nil
+	}
+	hash, err := t.hasher.Hash(key)
+	if err != nil {
+		return err
+	}
+	if t.sameHash(hash) >= len(t.tables) && t.overflow() >= cuckooStashSize {
+		return ErrTooManyCollisions
+	}
+	t.size++
+	entry := cuckooSlot[K, T]{key: key, value: val, hash: hash, used: true}
+	if float64(t.size) > float64(t.Capacity())*cuckooMaxLoadFactor {
+		t.rebuild(t.capacity<<1, append(t.entries(), entry))
+		return nil
+	}
+	if homeless, ok := t.place(entry); !ok {
+		t.rebuild(t.capacity, append(t.entries(), homeless))
+	}
+	return nil
+}
+
+// place kicks entries between their candidate slots,
+// the entry left without a slot is returned when both the kicks and the stash are exhausted
+func (t *CuckooHashTable[K, T]) place(s cuckooSlot[K, T]) (cuckooSlot[K, T], bool) {
+	for kick := 0; kick < cuckooMaxKicks; kick++ {
+		for i := range t.tables {
+			j := t.index(i, s.hash)
+			if !t.tables[i][j].used {
+				t.tables[i][j] = s
+				return cuckooSlot[K, T]{}, true
+			}
+		}
+		i := kick % len(t.tables)
+		j := t.index(i, s.hash)
+		s, t.tables[i][j] = t.tables[i][j], s
+	}
+	if len(t.stash) < cuckooStashSize {
+		t.stash = append(t.stash, s)
+		return cuckooSlot[K, T]{}, true
+	}
+	return s, false
+}
+
+func (t *CuckooHashTable[K, T]) entries() []cuckooSlot[K, T] {
+	entries := make([]cuckooSlot[K, T], 0, t.size)
+	for _, table := range t.tables {
+		for _, s := range table {
+			if s.used {
+				entries = append(entries, s)
+			}
+		}
+	}
+	return append(entries, t.stash...)
+}
+
+var ErrTooManyCollisions = errors.New("too many keys with equal hashes")
+
+// sameHash counts the keys with hash, they can only be in its candidate slots or the stash
+func (t *CuckooHashTable[K, T]) sameHash(hash uint64) int {
+	var n int
+	for i := range t.tables {
+		if s := t.tables[i][t.index(i, hash)]; s.used && s.hash == hash {
+			n++
+		}
+	}
+	for _, s := range t.stash {
+		if s.hash == hash {
+			n++
+		}
+	}
+	return n
+}
+
+// overflow counts the keys which stay in the stash with any seeds and capacity,
+// those beyond functions keys with an equal hash
+func (t *CuckooHashTable[K, T]) overflow() int {
+	var n int
+	seen := make(map[uint64]bool, len(t.stash))
+	for _, s := range t.stash {
+		if !seen[s.hash] {
+			seen[s.hash] = true
+			n += max(t.sameHash(s.hash)-len(t.tables), 0)
+		}
+	}
+	return n
+}
+
+// rebuild retries new seeds up to cuckooMaxRehashes times and then doubles the capacity,
+// Put doesn't let in keys with equal hashes which overflow the stash, so it always ends
+func (t *CuckooHashTable[K, T]) rebuild(capacity uint64, entries []cuckooSlot[K, T]) {
+	for attempt := 1; ; attempt++ {
+		if attempt > cuckooMaxRehashes && capacity < maxCapacity {
+			capacity <<= 1
+			attempt = 1
+		}
+		t.capacity = capacity
+		t.reseed()
+		for i := range t.tables {
+			t.tables[i] = make([]cuckooSlot[K, T], capacity)
+		}
+		t.stash = t.stash[:0]
+		placed := true
+		for _, s := range entries {
+			if _, placed = t.place(s); !placed {
+				break
+			}
+		}
+		if placed {
+			return
+		}
+	}
+}
+
+func (t *CuckooHashTable[K, T]) Remove(key K) error {
+	s, err := t.find(key)
+	if err != nil || s == nil {
+		return newKeyNotFoundError(key, err)
+	}
+	t.size--
+	for i := range t.stash {
+		if &t.stash[i] == s {
+			t.stash = append(t.stash[:i], t.stash[i+1:]...)
+			return nil
+		}
+	}
+	*s = cuckooSlot[K, T]{}
+	return nil
+}
+
+func (t *CuckooHashTable[K, T]) Foreach(tFunc HashTableFunc[T]) {
+	for _, s := range t.entries() {
+		tFunc(s.value)
+	}
+}
+
+func (t *CuckooHashTable[K, T]) Keys() []K {
+	var keys []K
+	for _, s := range t.entries() {
+		keys = append(keys, s.key)
+	}
+	return keys
+}
+
+func (t *CuckooHashTable[K, T]) Values() []T {
+	var values []T
+	for _, s := range t.entries() {
+		values = append(values, s.value)
+	}
+	return values
+}
+
+func (t *CuckooHashTable[K, T]) Clear() {
+	t.size = 0
+	for i := range t.tables {
+		clear(t.tables[i])
+	}
+	t.stash = nil
+}
+
+func NewCuckooHashTable[K comparable, T any](opts ...Option[K]) *CuckooHashTable[K, T] {
+	t, _ := NewCuckooHashTableWithCapacity[K, T](defaultCapacity, defaultCuckooFunctions, opts...)
+	return t
+}
+
+var ErrTooFewHashFunctions = errors.New("cuckoo hashing needs at least 2 hash functions")
+
+// NewCuckooHashTableWithCapacity rounds capacity of each of the functions tables up to a power of two
+func NewCuckooHashTableWithCapacity[K comparable, T any](capacity uint64, functions int, opts ...Option[K]) (*CuckooHashTable[K, T], error) {
+	if err := checkCapacity(capacity); err != nil {
+		return new(CuckooHashTable[K, T]), err
+	}
+	if functions < 2 {
+		return new(CuckooHashTable[K, T]), ErrTooFewHashFunctions
+	}
+	capacity = roundUpPowerOfTwo(capacity)
+	o := newOptions(opts)
+	t := &CuckooHashTable[K, T]{
+		capacity: capacity,
+		seeds:    make([]uint64, functions),
+		tables:   make([][]cuckooSlot[K, T], functions),
+		hasher:   o.hasher,
+	}
+	for i := range t.tables {
+		t.tables[i] = make([]cuckooSlot[K, T], capacity)
+	}
+	t.reseed()
+	return t, nil
+}
@@ -0,0 +1,224 @@
+package hash_table
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestNewCuckooHashTableWithCapacity(t *testing.T) {
+	type testCase struct {
+		name      string
+		capacity  uint64
+		functions int
+		want      uint64
+		wantErr   error
+	}
+	tests := []testCase{
+		{name: "got error when empty", capacity: 0, functions: 2, wantErr: ErrCapacityIsEmpty},
+		{name: "got error with one function", capacity: 16, functions: 1, wantErr: ErrTooFewHashFunctions},
+		{name: "two functions", capacity: 16, functions: 2, want: 32},
+		{name: "rounded up", capacity: 100, functions: 3, want: 384},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCuckooHashTableWithCapacity[int, int](tt.capacity, tt.functions)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got.Capacity())
+			assert.Equal(t, tt.functions, got.Functions())
+		})
+	}
+}
+
+func TestCuckooHashTable_PutGet(t *testing.T) {
+	ct := NewCuckooHashTable[int, *people]()
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, ct.Put(i, &people{name: "test", age: i}))
+	}
+	assert.NoError(t, ct.Put(5, &people{name: "updated", age: 5}))
+	assert.Equal(t, uint64(100), ct.Size())
+	assert.LessOrEqual(t, float64(ct.Size()), float64(ct.Capacity())*cuckooMaxLoadFactor)
+
+	for i := 0; i < 100; i++ {
+		got, err := ct.Get(i)
+		assert.NoError(t, err)
+		assert.Equal(t, i, got.age)
+	}
+	got, _ := ct.Get(5)
+	assert.Equal(t, "updated", got.name)
+
+	_, err := ct.Get(100)
+	assert.ErrorIs(t, err, ErrElementIsEmptyByKey)
+	_, ok := ct.Lookup(100)
+	assert.False(t, ok)
+	ok, err = ct.Contains(100)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestCuckooHashTable_Random(t *testing.T) {
+	for _, functions := range []int{2, 3, 4} {
+		t.Run(fmt.Sprint(functions), func(t *testing.T) {
+			rnd := rand.New(rand.NewSource(1))
+			ct, _ := NewCuckooHashTableWithCapacity[int, int](16, functions, WithHasher[int](IntegerHasher[int]{}))
+			want := make(map[int]int)
+			for i := 0; i < 20000; i++ {
+				key := rnd.Intn(2000)
+				if rnd.Intn(3) == 0 {
+					_, ok := want[key]
+					err := ct.Remove(key)
+					assert.Equal(t, ok, err == nil)
+					delete(want, key)
+					continue
+				}
+				want[key] = i
+				assert.NoError(t, ct.Put(key, i))
+			}
+			assert.Equal(t, uint64(len(want)), ct.Size())
+			assert.Len(t, ct.Keys(), len(want))
+			assert.Len(t, ct.Values(), len(want))
+			assert.LessOrEqual(t, len(ct.stash), cuckooStashSize)
+			for k, v := range want {
+				got, err := ct.Get(k)
+				assert.NoError(t, err)
+				assert.Equal(t, v, got)
+			}
+		})
+	}
+}
+
+func TestCuckooHashTable_CandidateSlots(t *testing.T) {
+	ct := NewCuckooHashTable[int, int](WithHasher[int](IntegerHasher[int]{}))
+	for i := 0; i < 5000; i++ {
+		assert.NoError(t, ct.Put(i, i))
+	}
+	for i := 0; i < 5000; i++ {
+		hash, _ := ct.hasher.Hash(i)
+		inTable := false
+		for table := range ct.tables {
+			s := ct.tables[table][ct.index(table, hash)]
+			inTable = inTable || (s.used && s.key == i)
+		}
+		inStash := false
+		for _, s := range ct.stash {
+			inStash = inStash || s.key == i
+		}
+		assert.Truef(t, inTable || inStash, "key %v must be in a candidate slot or the stash", i)
+	}
+}
+
+func TestCuckooHashTable_Cycle(t *testing.T) {
+	ct, _ := NewCuckooHashTableWithCapacity[int, int](2, 2, WithHasher[int](IntegerHasher[int]{}))
+	entry := func(key int) cuckooSlot[int, int] {
+		hash, _ := ct.hasher.Hash(key)
+		return cuckooSlot[int, int]{key: key, value: key, hash: hash, used: true}
+	}
+	// every slot and the stash are taken so the kicks can only go round
+	for i := range ct.tables {
+		for j := range ct.tables[i] {
+			ct.tables[i][j] = entry(i*2 + j)
+		}
+	}
+	for i := 4; i < 4+cuckooStashSize; i++ {
+		ct.stash = append(ct.stash, entry(i))
+	}
+	ct.size = 9
+	homeless, ok := ct.place(entry(8))
+	assert.False(t, ok)
+
+	seeds := append([]uint64(nil), ct.seeds...)
+	ct.rebuild(ct.capacity, append(ct.entries(), homeless))
+	assert.NotEqual(t, seeds, ct.seeds, "cycle must rehash with new seeds")
+	assert.LessOrEqual(t, len(ct.stash), cuckooStashSize)
+	for i := 0; i < 9; i++ {
+		got, err := ct.Get(i)
+		assert.NoError(t, err)
+		assert.Equal(t, i, got)
+	}
+}
+
+func TestCuckooHashTable_Grow(t *testing.T) {
+	// 2 slots and the stash fit 6 keys, so the 7th can only fit after the capacity grows
+	ct, _ := NewCuckooHashTableWithCapacity[int, int](1, 2, WithHasher[int](IntegerHasher[int]{}))
+	var entries []cuckooSlot[int, int]
+	for i := 0; i < 7; i++ {
+		hash, _ := ct.hasher.Hash(i)
+		entries = append(entries, cuckooSlot[int, int]{key: i, value: i, hash: hash, used: true})
+	}
+	ct.size = 7
+	ct.rebuild(ct.capacity, entries)
+	assert.Greater(t, ct.capacity, uint64(1))
+	assert.LessOrEqual(t, len(ct.stash), cuckooStashSize)
+	for i := 0; i < 7; i++ {
+		got, err := ct.Get(i)
+		assert.NoError(t, err)
+		assert.Equal(t, i, got)
+	}
+}
+
+func TestCuckooHashTable_EqualHashes(t *testing.T) {
+	ct := NewCuckooHashTable[int, int](collidingHasher())
+	// keys with one hash fit the candidate slots and the stash only
+	limit := ct.Functions() + cuckooStashSize
+	for i := 0; i < limit; i++ {
+		assert.NoError(t, ct.Put(i, i))
+	}
+	assert.ErrorIs(t, ct.Put(limit, limit), ErrTooManyCollisions)
+	assert.Equal(t, uint64(limit), ct.Size())
+	assert.LessOrEqual(t, len(ct.stash), cuckooStashSize)
+	assert.NoError(t, ct.Put(0, 100), "updates of stored keys must still work")
+	for i := 0; i < limit; i++ {
+		ok, _ := ct.Contains(i)
+		assert.True(t, ok)
+	}
+	ok, _ := ct.Contains(limit)
+	assert.False(t, ok)
+
+	assert.NoError(t, ct.Remove(1))
+	assert.NoError(t, ct.Put(limit, limit))
+	got, err := ct.Get(limit)
+	assert.NoError(t, err)
+	assert.Equal(t, limit, got)
+}
+
+func TestCuckooHashTable_HasherError(t *testing.T) {
+	ct := NewCuckooHashTable[int, int]()
+	h := MockHasher[int]{}
+	h.On("Hash", mock.AnythingOfType("int")).
+		Return(uint64(0), errors.New("test err"))
+	ct.hasher = &h
+
+	_, err := ct.Get(1)
+	assert.Error(t, err)
+	assert.Error(t, ct.Put(1, 1))
+	assert.Error(t, ct.Remove(1))
+	_, err = ct.Contains(1)
+	assert.Error(t, err)
+	assert.True(t, ct.IsEmpty())
+}
+
+func TestCuckooHashTable_Clear(t *testing.T) {
+	ct := NewCuckooHashTable[int, int]()
+	for i := 0; i < 10; i++ {
+		_ = ct.Put(i, i)
+	}
+	keys := ct.Keys()
+	sort.Ints(keys)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, keys)
+
+	ct.Clear()
+	assert.True(t, ct.IsEmpty())
+	assert.Nil(t, ct.Keys())
+
+	var sum int
+	_ = ct.Put(1, 10)
+	ct.Foreach(func(val int) { sum += val })
+	assert.Equal(t, 10, sum)
+}
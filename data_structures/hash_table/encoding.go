@@ -0,0 +1,132 @@
+package hash_table
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var ErrUnsupportedKeyType = errors.New("unsupported key type")
+
+type entry[K comparable, T any] struct {
+	Key   K `json:"key"`
+	Value T `json:"value"`
+}
+
+type encodedTable[K comparable, T any] struct {
+	Capacity uint64        `json:"capacity"`
+	Entries  []entry[K, T] `json:"entries"`
+}
+
+// checkKeyType rejects keys which cannot be restored with the same identity,
+// interface keys lose their dynamic type and complex numbers aren't supported by json
+func checkKeyType[K comparable](forJSON bool) error {
+	kt := reflect.TypeFor[K]()
+	switch kt.Kind() {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Interface, reflect.Pointer:
+		return fmt.Errorf("%w %v", ErrUnsupportedKeyType, kt)
+	case reflect.Complex64, reflect.Complex128:
+		if forJSON {
+			return fmt.Errorf("%w %v", ErrUnsupportedKeyType, kt)
+		}
+	}
+	return nil
+}
+
+func (t *HashTable[K, T]) encode() encodedTable[K, T] {
+	et := encodedTable[K, T]{Capacity: t.capacity, Entries: make([]entry[K, T], 0, t.size)}
+	for _, el := range t.table {
+		for v := el; v != nil; v = v.next {
+			et.Entries = append(et.Entries, entry[K, T]{Key: v.key, Value: v.value})
+		}
+	}
+	return et
+}
+
+// maxDecodedCapacity bounds the buckets allocated for a payload,
+// a larger capacity is accepted only when that many entries could have grown the table so far
+const maxDecodedCapacity uint64 = 1 << 20
+
+func (t *HashTable[K, T]) decode(et encodedTable[K, T]) error {
+	if err := checkCapacity(et.Capacity); err != nil {
+		return err
+	}
+	et.Capacity = roundUpPowerOfTwo(et.Capacity)
+	if limit := max(maxDecodedCapacity, roundUpPowerOfTwo(uint64(len(et.Entries)))<<3); et.Capacity > limit {
+		return fmt.Errorf("%w: %v buckets for %v entries", ErrCapacityIsTooLarge, et.Capacity, len(et.Entries))
+	}
+	// entries go to a fresh table, so t keeps its contents when one of them fails
+	decoded := &HashTable[K, T]{
+		capacity:       et.Capacity,
+		minCapacity:    et.Capacity,
+		lowLoadFactor:  t.lowLoadFactor,
+		highLoadFactor: t.highLoadFactor,
+		table:          make([]*node[K, T], et.Capacity),
+		hasher:         t.hasher,
+	}
+	if decoded.hasher == nil {
+		decoded.hasher = FNVHasher[K]{}
+	}
+	if decoded.highLoadFactor == 0 {
+		decoded.lowLoadFactor, decoded.highLoadFactor = defaultLowLoadFactor, defaultHighLoadFactor
+	}
+	for _, e := range et.Entries {
+		if err := decoded.Put(e.Key, e.Value); err != nil {
+			return err
+		}
+	}
+	decoded.modCount = t.modCount + 1
+	*t = *decoded
+	return nil
+}
+
+func (t *HashTable[K, T]) MarshalJSON() ([]byte, error) {
+	if err := checkKeyType[K](true); err != nil {
+		return nil, err
+	}
+	return json.Marshal(t.encode())
+}
+
+func (t *HashTable[K, T]) UnmarshalJSON(data []byte) error {
+	if err := checkKeyType[K](true); err != nil {
+		return err
+	}
+	var et encodedTable[K, T]
+	if err := json.Unmarshal(data, &et); err != nil {
+		return err
+	}
+	return t.decode(et)
+}
+
+func (t *HashTable[K, T]) MarshalBinary() ([]byte, error) {
+	if err := checkKeyType[K](false); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(t.encode()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (t *HashTable[K, T]) UnmarshalBinary(data []byte) error {
+	if err := checkKeyType[K](false); err != nil {
+		return err
+	}
+	var et encodedTable[K, T]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&et); err != nil {
+		return err
+	}
+	return t.decode(et)
+}
+
+func (t *HashTable[K, T]) GobEncode() ([]byte, error) {
+	return t.MarshalBinary()
+}
+
+func (t *HashTable[K, T]) GobDecode(data []byte) error {
+	return t.UnmarshalBinary(data)
+}
@@ -0,0 +1,155 @@
+package hash_table
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+type city struct {
+	Name       string
+	Population int
+}
+
+func fetchCities() *HashTable[string, city] {
+	ht, _ := NewHashTableWithCapacity[string, city](64)
+	_ = ht.Put("msk", city{Name: "Moscow", Population: 13})
+	_ = ht.Put("spb", city{Name: "Saint Petersburg", Population: 5})
+	_ = ht.Put("kzn", city{Name: "Kazan", Population: 1})
+	return ht
+}
+
+func assertSameTable[K comparable, T any](t *testing.T, want, got *HashTable[K, T]) {
+	assert.Equal(t, want.Capacity(), got.Capacity())
+	assert.Equal(t, want.Size(), got.Size())
+	for k, v := range want.All() {
+		gotVal, err := got.Get(k)
+		assert.NoError(t, err)
+		assert.Equal(t, v, gotVal)
+	}
+}
+
+func TestHashTable_JSON(t *testing.T) {
+	want := fetchCities()
+	data, err := json.Marshal(want)
+	assert.NoError(t, err)
+
+	got := NewHashTable[string, city]()
+	assert.NoError(t, json.Unmarshal(data, got))
+	assertSameTable(t, want, got)
+
+	var zero HashTable[string, city]
+	assert.NoError(t, json.Unmarshal(data, &zero))
+	assertSameTable(t, want, &zero)
+	assert.NoError(t, zero.Put("ekb", city{Name: "Yekaterinburg"}))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"capacity": "big"}`), got))
+}
+
+func TestHashTable_JSONCapacity(t *testing.T) {
+	type testCase struct {
+		name    string
+		data    string
+		want    uint64
+		wantErr error
+	}
+	tests := []testCase{
+		{name: "rounded up", data: `{"capacity": 150, "entries": [{"key": 1, "value": 1}]}`, want: 256},
+		{name: "grown by entries", data: `{"capacity": 2, "entries": [{"key": 1, "value": 1}, {"key": 2, "value": 2}]}`, want: 4},
+		{name: "empty", data: `{"capacity": 0}`, wantErr: ErrCapacityIsEmpty},
+		{name: "missing", data: `{"entries": []}`, wantErr: ErrCapacityIsEmpty},
+		{name: "absurd", data: `{"capacity": 1000000000000000}`, wantErr: ErrCapacityIsTooLarge},
+		{name: "overflowing", data: `{"capacity": 18446744073709551615}`, wantErr: ErrCapacityIsTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewHashTable[int, int]()
+			err := json.Unmarshal([]byte(tt.data), got)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got.Capacity())
+			assert.Len(t, got.table, int(tt.want))
+		})
+	}
+}
+
+func TestHashTable_JSONHasherError(t *testing.T) {
+	got := NewHashTable[int, int]()
+	h := MockHasher[int]{}
+	h.On("Hash", 2).Return(uint64(0), errors.New("test err"))
+	h.On("Hash", mock.AnythingOfType("int")).Return(uint64(0), nil)
+	got.hasher = &h
+	assert.NoError(t, got.Put(10, 10))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"capacity": 64, "entries": [{"key": 1, "value": 1}, {"key": 2, "value": 2}]}`), got))
+	assert.Equal(t, uint64(1), got.Size(), "a failed decode must keep the contents")
+	assert.Equal(t, defaultCapacity, got.Capacity())
+	assert.Equal(t, []int{10}, got.Keys())
+}
+
+func TestHashTable_JSONStructKey(t *testing.T) {
+	want := NewHashTable[city, int]()
+	_ = want.Put(city{Name: "Moscow"}, 1)
+	_ = want.Put(city{Name: "Kazan"}, 2)
+	data, err := json.Marshal(want)
+	assert.NoError(t, err)
+
+	got := NewHashTable[city, int]()
+	assert.NoError(t, json.Unmarshal(data, got))
+	assertSameTable(t, want, got)
+}
+
+func TestHashTable_Binary(t *testing.T) {
+	want := fetchCities()
+	data, err := want.MarshalBinary()
+	assert.NoError(t, err)
+
+	got := NewHashTable[string, city]()
+	assert.NoError(t, got.UnmarshalBinary(data))
+	assertSameTable(t, want, got)
+
+	assert.Error(t, got.UnmarshalBinary([]byte("broken")))
+}
+
+func TestHashTable_Gob(t *testing.T) {
+	type payload struct {
+		Cities *HashTable[string, city]
+	}
+	want := payload{Cities: fetchCities()}
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(want))
+
+	var got payload
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&got))
+	assertSameTable(t, want.Cities, got.Cities)
+}
+
+func TestHashTable_UnsupportedKeyType(t *testing.T) {
+	withInterface := NewHashTable[any, int]()
+	_ = withInterface.Put(1, 1)
+	_, err := json.Marshal(withInterface)
+	assert.ErrorIs(t, err, ErrUnsupportedKeyType)
+	_, err = withInterface.MarshalBinary()
+	assert.ErrorIs(t, err, ErrUnsupportedKeyType)
+	assert.ErrorIs(t, withInterface.UnmarshalJSON([]byte(`{}`)), ErrUnsupportedKeyType)
+
+	withComplex := NewHashTable[complex64, int]()
+	_ = withComplex.Put(1+2i, 1)
+	_, err = json.Marshal(withComplex)
+	assert.ErrorIs(t, err, ErrUnsupportedKeyType)
+	data, err := withComplex.MarshalBinary()
+	assert.NoError(t, err)
+	got := NewHashTable[complex64, int]()
+	assert.NoError(t, got.UnmarshalBinary(data))
+	assertSameTable(t, withComplex, got)
+
+	withChan := NewHashTable[chan int, int]()
+	_, err = withChan.MarshalBinary()
+	assert.ErrorIs(t, err, ErrUnsupportedKeyType)
+}
@@ -0,0 +1,198 @@
+package hash_table
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker delivers ticks of a Clock like time.Ticker
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+type expiringEntry[T any] struct {
+	value    T
+	expireAt time.Time
+}
+
+func (e expiringEntry[T]) expired(now time.Time) bool {
+	return !e.expireAt.IsZero() && !now.Before(e.expireAt)
+}
+
+// ExpiringHashTable treats expired entries as missing,
+// they are removed lazily on access or by the janitor
+type ExpiringHashTable[K comparable, T any] struct {
+	mu    sync.Mutex
+	clock Clock
+	table *HashTable[K, expiringEntry[T]]
+}
+
+// Size counts expired entries which aren't removed yet
+func (t *ExpiringHashTable[K, T]) Size() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.table.Size()
+}
+
+func (t *ExpiringHashTable[K, T]) IsEmpty() bool {
+	return t.Size() < 1
+}
+
+// Put stores val for ttl, ttl <= 0 means the entry never expires
+func (t *ExpiringHashTable[K, T]) Put(key K, val T, ttl time.Duration) error {
+	e := expiringEntry[T]{value: val}
+	if ttl > 0 {
+		e.expireAt = t.clock.Now().Add(ttl)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.table.Put(key, e)
+}
+
+func (t *ExpiringHashTable[K, T]) Get(key K) (T, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n, err := t.table.lookup(key)
+	if err != nil {
+		return *new(T), newKeyNotFoundError(key, err)
+	}
+	if n == nil || t.expire(n) {
+		return *new(T), newKeyNotFoundError(key, nil)
+	}
+	return n.value.value, nil
+}
+
+func (t *ExpiringHashTable[K, T]) Lookup(key K) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n, err := t.table.lookup(key)
+	if err != nil || n == nil || t.expire(n) {
+		return *new(T), false
+	}
+	return n.value.value, true
+}
+
+func (t *ExpiringHashTable[K, T]) Contains(key K) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n, err := t.table.lookup(key)
+	if err != nil {
+		return false, newKeyNotFoundError(key, err)
+	}
+	return n != nil && !t.expire(n), nil
+}
+
+// TTL returns the remaining time to live, zero for entries without expiration
+func (t *ExpiringHashTable[K, T]) TTL(key K) (time.Duration, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n, err := t.table.lookup(key)
+	if err != nil {
+		return 0, newKeyNotFoundError(key, err)
+	}
+	if n == nil || t.expire(n) {
+		return 0, newKeyNotFoundError(key, nil)
+	}
+	if n.value.expireAt.IsZero() {
+		return 0, nil
+	}
+	return n.value.expireAt.Sub(t.clock.Now()), nil
+}
+
+func (t *ExpiringHashTable[K, T]) Remove(key K) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.table.Remove(key)
+}
+
+// expire removes the entry of n when it is expired
+func (t *ExpiringHashTable[K, T]) expire(n *node[K, expiringEntry[T]]) bool {
+	if !n.value.expired(t.clock.Now()) {
+		return false
+	}
+	_ = t.table.Remove(n.key)
+	return true
+}
+
+// DeleteExpired removes all expired entries and returns their count
+func (t *ExpiringHashTable[K, T]) DeleteExpired() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := t.clock.Now()
+	var expired []K
+	for key, e := range t.table.All() {
+		if e.expired(now) {
+			expired = append(expired, key)
+		}
+	}
+	for _, key := range expired {
+		_ = t.table.Remove(key)
+	}
+	return len(expired)
+}
+
+var ErrInvalidInterval = errors.New("janitor interval must be positive")
+
+// StartJanitor calls DeleteExpired on every tick of the table clock until ctx is done,
+// the returned channel is closed when the janitor exits
+func (t *ExpiringHashTable[K, T]) StartJanitor(ctx context.Context, interval time.Duration) (<-chan struct{}, error) {
+	if interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	ticker := t.clock.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+				t.DeleteExpired()
+			}
+		}
+	}()
+	return done, nil
+}
+
+func NewExpiringHashTable[K comparable, T any](opts ...Option[K]) *ExpiringHashTable[K, T] {
+	return NewExpiringHashTableWithClock[K, T](systemClock{}, opts...)
+}
+
+// NewExpiringHashTableWithClock takes expiration time and janitor ticks from clock, nil means the system clock
+func NewExpiringHashTableWithClock[K comparable, T any](clock Clock, opts ...Option[K]) *ExpiringHashTable[K, T] {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	return &ExpiringHashTable[K, T]{
+		clock: clock,
+		table: NewHashTable[K, expiringEntry[T]](opts...),
+	}
+}
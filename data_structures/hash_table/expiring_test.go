@@ -0,0 +1,208 @@
+package hash_table
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTicker{c: make(chan time.Time, 1), interval: d, next: c.now.Add(d), clock: c}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+// Advance fires the tickers which are due, like time.Ticker a slow reader misses ticks
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		for !t.stopped && !t.next.After(c.now) {
+			select {
+			case t.c <- c.now:
+			default:
+			}
+			t.next = t.next.Add(t.interval)
+		}
+	}
+}
+
+type fakeTicker struct {
+	c        chan time.Time
+	interval time.Duration
+	next     time.Time
+	stopped  bool
+	clock    *fakeClock
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.stopped = true
+}
+
+func (t *fakeTicker) isStopped() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.stopped
+}
+
+func fetchExpiringHashTable() (*ExpiringHashTable[string, int], *fakeClock) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	et := NewExpiringHashTableWithClock[string, int](clock)
+	_ = et.Put("second", 1, time.Second)
+	_ = et.Put("minute", 2, time.Minute)
+	_ = et.Put("forever", 3, 0)
+	return et, clock
+}
+
+func TestExpiringHashTable_Get(t *testing.T) {
+	type testCase struct {
+		name    string
+		advance time.Duration
+		key     string
+		want    int
+		wantErr assert.ErrorAssertionFunc
+	}
+	tests := []testCase{
+		{name: "not expired", advance: 999 * time.Millisecond, key: "second", want: 1, wantErr: assert.NoError},
+		{name: "expired on deadline", advance: time.Second, key: "second", wantErr: assert.Error},
+		{name: "longer ttl", advance: time.Second, key: "minute", want: 2, wantErr: assert.NoError},
+		{name: "never expires", advance: 24 * time.Hour, key: "forever", want: 3, wantErr: assert.NoError},
+		{name: "absent", key: "absent", wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			et, clock := fetchExpiringHashTable()
+			clock.Advance(tt.advance)
+			got, err := et.Get(tt.key)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			if err != nil {
+				assert.ErrorIs(t, err, ErrElementIsEmptyByKey)
+			}
+			assert.Equal(t, tt.want, got)
+
+			lookup, ok := et.Lookup(tt.key)
+			assert.Equal(t, err == nil, ok)
+			assert.Equal(t, tt.want, lookup)
+			contains, cErr := et.Contains(tt.key)
+			assert.NoError(t, cErr)
+			assert.Equal(t, err == nil, contains)
+		})
+	}
+}
+
+func TestExpiringHashTable_LazyRemove(t *testing.T) {
+	et, clock := fetchExpiringHashTable()
+	clock.Advance(time.Second)
+	assert.Equal(t, uint64(3), et.Size())
+	_, ok := et.Lookup("second")
+	assert.False(t, ok)
+	assert.Equal(t, uint64(2), et.Size())
+
+	assert.NoError(t, et.Put("second", 10, time.Second))
+	got, err := et.Get("second")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, got)
+}
+
+func TestExpiringHashTable_TTL(t *testing.T) {
+	et, clock := fetchExpiringHashTable()
+	clock.Advance(10 * time.Second)
+	ttl, err := et.TTL("minute")
+	assert.NoError(t, err)
+	assert.Equal(t, 50*time.Second, ttl)
+	ttl, err = et.TTL("forever")
+	assert.NoError(t, err)
+	assert.Zero(t, ttl)
+	_, err = et.TTL("second")
+	assert.ErrorIs(t, err, ErrElementIsEmptyByKey)
+}
+
+func TestExpiringHashTable_DeleteExpired(t *testing.T) {
+	et, clock := fetchExpiringHashTable()
+	assert.Zero(t, et.DeleteExpired())
+	clock.Advance(time.Minute)
+	assert.Equal(t, 2, et.DeleteExpired())
+	assert.Equal(t, uint64(1), et.Size())
+	assert.NoError(t, et.Remove("forever"))
+	assert.True(t, et.IsEmpty())
+}
+
+func TestExpiringHashTable_StartJanitor(t *testing.T) {
+	et, clock := fetchExpiringHashTable()
+	ctx, cancel := context.WithCancel(context.Background())
+	done, err := et.StartJanitor(ctx, time.Hour)
+	assert.NoError(t, err)
+
+	clock.Advance(time.Minute)
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, uint64(3), et.Size(), "janitor must wait for a tick of the clock")
+	clock.Advance(time.Hour)
+	assert.Eventually(t, func() bool {
+		return et.Size() == 1
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("janitor didn't stop after cancel")
+	}
+	assert.True(t, clock.tickers[0].isStopped())
+
+	_ = et.Put("late", 1, time.Second)
+	clock.Advance(time.Hour)
+	assert.Equal(t, uint64(2), et.Size(), "stopped janitor must not sweep")
+}
+
+func TestExpiringHashTable_StartJanitorInterval(t *testing.T) {
+	et, clock := fetchExpiringHashTable()
+	for _, interval := range []time.Duration{0, -time.Second} {
+		done, err := et.StartJanitor(context.Background(), interval)
+		assert.ErrorIs(t, err, ErrInvalidInterval)
+		assert.Nil(t, done)
+	}
+	assert.Empty(t, clock.tickers)
+}
+
+func TestNewExpiringHashTable_SystemClock(t *testing.T) {
+	for _, et := range []*ExpiringHashTable[string, int]{
+		NewExpiringHashTable[string, int](),
+		NewExpiringHashTableWithClock[string, int](nil, WithHasher[string](StringHasher[string]{})),
+	} {
+		assert.NoError(t, et.Put("a", 1, time.Hour))
+		ttl, err := et.TTL("a")
+		assert.NoError(t, err)
+		assert.InDelta(t, time.Hour, ttl, float64(time.Second))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done, err := et.StartJanitor(ctx, time.Millisecond)
+		assert.NoError(t, err)
+		cancel()
+		<-done
+	}
+}
@@ -3,10 +3,16 @@ package hash_table
 import (
 	"errors"
 	"fmt"
-	"hash/fnv"
+	"math/bits"
 )
 
-const defaultCapacity uint64 = 10 << 10
+const (
+	defaultCapacity       uint64  = 1 << 4
+	defaultLowLoadFactor  float64 = 0.25
+	defaultHighLoadFactor float64 = 0.75
+	// maxCapacity is the largest power of two a uint64 holds
+	maxCapacity uint64 = 1 << 63
+)
 
 type node[K comparable, T any] struct {
 	key   K
@@ -14,29 +20,31 @@ type node[K comparable, T any] struct {
 	next  *node[K, T]
 }
 
-type Hasher[K comparable] interface {
-	makeHash(key K) (uint64, error)
+type HashTable[K comparable, T any] struct {
+	size           uint64
+	capacity       uint64
+	modCount       uint64
+	minCapacity    uint64
+	lowLoadFactor  float64
+	highLoadFactor float64
+	table          []*node[K, T]
+	hasher         Hasher[K]
 }
 
-type hashMaker[K comparable] struct {
-	capacity uint64
+// roundUpPowerOfTwo keeps capacity-1 a valid bucket mask
+func roundUpPowerOfTwo(n uint64) uint64 {
+	if n&(n-1) == 0 {
+		return n
+	}
+	return 1 << bits.Len64(n)
 }
 
-func (h *hashMaker[K]) makeHash(key K) (uint64, error) {
-	hs := fnv.New64()
-	if _, err := hs.Write([]byte(fmt.Sprintf("%v", key))); err != nil {
+func (t *HashTable[K, T]) index(key K) (uint64, error) {
+	hashVal, err := t.hasher.Hash(key)
+	if err != nil {
 		return 0, err
 	}
-	hashVal := hs.Sum64()
-	hashVal = (h.capacity - 1) & (hashVal ^ (hashVal >> 16))
-	return hashVal, nil
-}
-
-type HashTable[K comparable, T any] struct {
-	size     uint64
-	capacity uint64
-	table    []*node[K, T]
-	hasher   Hasher[K]
+	return (t.capacity - 1) & (hashVal ^ (hashVal >> 16)), nil
 }
 
 func (t *HashTable[K, T]) newNode(key K, value T) *node[K, T] {
@@ -55,28 +63,119 @@ func (t *HashTable[K, T]) IsEmpty() bool {
 	return t.size < 1
 }
 
+func (t *HashTable[K, T]) LoadFactor() float64 {
+	if t.capacity < 1 {
+		return 0
+	}
+	return float64(t.size) / float64(t.capacity)
+}
+
+var ErrInvalidLoadFactor = errors.New("load factor thresholds must satisfy 0 <= low < high/2")
+
+// low must stay under high/2, otherwise shrink could immediately trigger grow
+func (t *HashTable[K, T]) SetLoadFactors(low, high float64) error {
+	if low < 0 || high <= 0 || low >= high/2 {
+		return ErrInvalidLoadFactor
+	}
+	t.lowLoadFactor = low
+	t.highLoadFactor = high
+	return nil
+}
+
+// Rehash rounds newCapacity up to a power of two
+func (t *HashTable[K, T]) Rehash(newCapacity uint64) error {
+	if err := checkCapacity(newCapacity); err != nil {
+		return err
+	}
+	newCapacity = roundUpPowerOfTwo(newCapacity)
+	oldCapacity := t.capacity
+	t.capacity = newCapacity
+	table := make([]*node[K, T], newCapacity)
+	for _, el := range t.table {
+		for v := el; v != nil; v = v.next {
+			hashIndex, err := t.index(v.key)
+			if err != nil {
+				t.capacity = oldCapacity
+				return err
+			}
+			table[hashIndex] = &node[K, T]{key: v.key, value: v.value, next: table[hashIndex]}
+		}
+	}
+	t.table = table
+	t.modCount++
+	return nil
+}
+
+func (t *HashTable[K, T]) grow() error {
+	if t.highLoadFactor <= 0 || t.LoadFactor() <= t.highLoadFactor {
+		return nil
+	}
+	return t.Rehash(t.capacity << 1)
+}
+
+func (t *HashTable[K, T]) shrink() error {
+	newCapacity := t.capacity >> 1
+	if newCapacity < t.minCapacity || t.LoadFactor() >= t.lowLoadFactor {
+		return nil
+	}
+	return t.Rehash(newCapacity)
+}
+
 var ErrElementIsEmptyByKey = errors.New("cannot find element by key")
 
+// KeyNotFoundError wraps ErrElementIsEmptyByKey and Err, the hasher failure if any
+type KeyNotFoundError[K comparable] struct {
+	Key K
+	Err error
+}
+
+func (e *KeyNotFoundError[K]) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%v %v: %v", ErrElementIsEmptyByKey, e.Key, e.Err)
+	}
+	return fmt.Sprintf("%v %v", ErrElementIsEmptyByKey, e.Key)
+}
+
+func (e *KeyNotFoundError[K]) Unwrap() []error {
+	if e.Err != nil {
+		return []error{ErrElementIsEmptyByKey, e.Err}
+	}
+	return []error{ErrElementIsEmptyByKey}
+}
+
+func newKeyNotFoundError[K comparable](key K, err error) error {
+	return &KeyNotFoundError[K]{Key: key, Err: err}
+}
+
+// Lookup doesn't allocate an error on miss, a hasher failure is reported as a miss
+func (t *HashTable[K, T]) Lookup(key K) (T, bool) {
+	n, err := t.lookup(key)
+	if err != nil || n == nil {
+		return *new(T), false
+	}
+	return n.value, true
+}
+
 func (t *HashTable[K, T]) Get(key K) (T, error) {
-	hashIndex, err := t.hasher.makeHash(key)
+	hashIndex, err := t.index(key)
 	if err != nil {
-		return *new(T), errors.Join(err, ErrElementIsEmptyByKey)
+		return *new(T), newKeyNotFoundError(key, err)
 	}
 	if t.table[hashIndex] == nil {
-		return *new(T), fmt.Errorf("%v %v", ErrElementIsEmptyByKey, key)
+		return *new(T), newKeyNotFoundError(key, nil)
 	}
 	for el := t.table[hashIndex]; el != nil; el = el.next {
 		if el.key == key {
 			return el.value, nil
 		}
 	}
-	return *new(T), fmt.Errorf("%v %v", ErrElementIsEmptyByKey, key)
+	return *new(T), newKeyNotFoundError(key, nil)
 }
 
 func (t *HashTable[K, T]) Contains(key K) (bool, error) {
-	hashIndex, err := t.hasher.makeHash(key)
+	hashIndex, err := t.index(key)
 	if err != nil {
-		return false, errors.Join(err, ErrElementIsEmptyByKey)
+		return false, newKeyNotFoundError(key, err)
 	}
 	if t.table[hashIndex] == nil {
 		return false, nil
@@ -89,25 +188,47 @@ func (t *HashTable[K, T]) Contains(key K) (bool, error) {
 	return false, nil
 }
 
+// lookup returns the node holding key or nil
+func (t *HashTable[K, T]) lookup(key K) (*node[K, T], error) {
+	hashIndex, err := t.index(key)
+	if err != nil {
+		return nil, err
+	}
+	for el := t.table[hashIndex]; el != nil; el = el.next {
+		if el.key == key {
+			return el, nil
+		}
+	}
+	return nil, nil
+}
+
 func (t *HashTable[K, T]) Put(key K, val T) error {
-	hashIndex, err := t.hasher.makeHash(key)
+	_, err := t.put(key, val)
+	return err
+}
+
+// put reports whether a new key was stored, it is stored even if the following grow fails
+func (t *HashTable[K, T]) put(key K, val T) (bool, error) {
+	hashIndex, err := t.index(key)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if t.table[hashIndex] == nil {
 		t.table[hashIndex] = t.newNode(key, val)
 		t.size++
-		return nil
+		t.modCount++
+		return true, t.grow()
 	}
 	for el := t.table[hashIndex]; el != nil; el = el.next {
 		if el.key == key {
 			el.value = val
-			return nil
+			return false, nil
 		}
 	}
 	t.resolvePutCollision(key, val, hashIndex)
 	t.size++
-	return nil
+	t.modCount++
+	return true, t.grow()
 }
 
 type HashTableFunc[T any] func(val T)
@@ -127,28 +248,35 @@ func (t *HashTable[K, T]) Foreach(tFunc HashTableFunc[T]) {
 
 func (t *HashTable[K, T]) Clear() {
 	t.size = 0
+	t.modCount++
 	for i, el := range t.table {
 		if el != nil {
 			t.table[i] = nil
 		}
 	}
-	fmt.Println("clear")
 }
 
 func (t *HashTable[K, T]) Remove(key K) error {
-	hashIndex, err := t.hasher.makeHash(key)
+	_, err := t.remove(key)
+	return err
+}
+
+// remove reports whether the key was removed, it is removed even if the following shrink fails
+func (t *HashTable[K, T]) remove(key K) (bool, error) {
+	hashIndex, err := t.index(key)
 	if err != nil {
-		return err
+		return false, newKeyNotFoundError(key, err)
 	}
 	if t.table[hashIndex] == nil {
-		return fmt.Errorf("%s %v", ErrElementIsEmptyByKey, key)
+		return false, newKeyNotFoundError(key, nil)
 	}
 	current := t.table[hashIndex]
 	if current.key == key {
 		t.table[hashIndex] = current.next
 		current = nil
 		t.size--
-		return nil
+		t.modCount++
+		return true, t.shrink()
 	}
 	for el := current; el.next != nil; el = el.next {
 		rmEl := el.next
@@ -156,10 +284,11 @@ func (t *HashTable[K, T]) Remove(key K) error {
 			el.next = rmEl.next
 			rmEl = nil
 			t.size--
-			return nil
+			t.modCount++
+			return true, t.shrink()
 		}
 	}
-	return fmt.Errorf("%s %v", ErrElementIsEmptyByKey, key)
+	return false, newKeyNotFoundError(key, nil)
 }
 
 func (t *HashTable[K, T]) Keys() []K {
@@ -201,26 +330,50 @@ func (t *HashTable[K, T]) resolvePutCollision(key K, val T, index uint64) {
 	}
 }
 
-func NewHashTable[K comparable, T any]() *HashTable[K, T] {
+func NewHashTable[K comparable, T any](opts ...Option[K]) *HashTable[K, T] {
+	o := newOptions(opts)
 	t := make([]*node[K, T], defaultCapacity)
 	return &HashTable[K, T]{
-		capacity: defaultCapacity,
-		table:    t,
-		hasher:   &hashMaker[K]{capacity: defaultCapacity},
+		capacity:       defaultCapacity,
+		minCapacity:    defaultCapacity,
+		lowLoadFactor:  defaultLowLoadFactor,
+		highLoadFactor: defaultHighLoadFactor,
+		table:          t,
+		hasher:         o.hasher,
 	}
 }
 
-var ErrCapacityIsEmpty = errors.New("size cannot be 0")
+var (
+	ErrCapacityIsEmpty    = errors.New("size cannot be 0")
+	ErrCapacityIsTooLarge = errors.New("capacity cannot exceed 2^63")
+)
 
-func NewHashTableWithCapacity[K comparable, T any](capacity uint64) (*HashTable[K, T], error) {
+// checkCapacity rejects capacities roundUpPowerOfTwo cannot round
+func checkCapacity(capacity uint64) error {
 	if capacity < 1 {
-		return new(HashTable[K, T]), ErrCapacityIsEmpty
+		return ErrCapacityIsEmpty
+	}
+	if capacity > maxCapacity {
+		return ErrCapacityIsTooLarge
+	}
+	return nil
+}
+
+// NewHashTableWithCapacity rounds capacity up to a power of two
+func NewHashTableWithCapacity[K comparable, T any](capacity uint64, opts ...Option[K]) (*HashTable[K, T], error) {
+	if err := checkCapacity(capacity); err != nil {
+		return new(HashTable[K, T]), err
 	}
+	capacity = roundUpPowerOfTwo(capacity)
+	o := newOptions(opts)
 	t := make([]*node[K, T], capacity)
 	ht := &HashTable[K, T]{
-		capacity: capacity,
-		table:    t,
-		hasher:   &hashMaker[K]{capacity: capacity},
+		capacity:       capacity,
+		minCapacity:    capacity,
+		lowLoadFactor:  defaultLowLoadFactor,
+		highLoadFactor: defaultHighLoadFactor,
+		table:          t,
+		hasher:         o.hasher,
 	}
 
 	return ht, nil
@@ -37,7 +37,7 @@ func TestHashTable_Get(t *testing.T) {
 			th: func() *HashTable[int, *people] {
 				t, _ := NewHashTableWithCapacity[int, *people](uint64(150))
 				h := MockHasher[int]{}
-				h.On("makeHash", mock.AnythingOfType("int")).
+				h.On("Hash", mock.AnythingOfType("int")).
 					Return(uint64(0), errors.New("test err"))
 				t.hasher = &h
 				return t
@@ -50,7 +50,7 @@ func TestHashTable_Get(t *testing.T) {
 			name: "got error when cannot find by key",
 			th: func() *HashTable[int, *people] {
 				t, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				t.table[132] = &node[int, *people]{value: &people{name: "test"}}
+				t.table[238] = &node[int, *people]{value: &people{name: "test"}}
 				return t
 			}(),
 			args:    1,
@@ -61,7 +61,7 @@ func TestHashTable_Get(t *testing.T) {
 			name: "got single element",
 			th: func() *HashTable[int, *people] {
 				t, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				t.table[132] = &node[int, *people]{value: &people{name: "test"}}
+				t.table[238] = &node[int, *people]{value: &people{name: "test"}}
 				return t
 			}(),
 			args: 0,
@@ -71,17 +71,17 @@ func TestHashTable_Get(t *testing.T) {
 			name: "got throw multiple elements",
 			th: func() *HashTable[int, *people] {
 				t, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				t.table[132] = &node[int, *people]{
+				t.table[238] = &node[int, *people]{
 					value: &people{name: "test"},
 					key:   0,
 					next: &node[int, *people]{
-						key:   2,
+						key:   453,
 						value: &people{name: "test1"},
 					},
 				}
 				return t
 			}(),
-			args: 2,
+			args: 453,
 			want: &people{name: "test1"},
 		},
 	}
@@ -97,7 +97,7 @@ func TestHashTable_Get(t *testing.T) {
 	}
 }
 
-func TestHashTable_makeHash(t *testing.T) {
+func TestHashTable_index(t *testing.T) {
 	type testCase[K comparable, T any] struct {
 		name    string
 		ht      *HashTable[K, T]
@@ -108,7 +108,7 @@ func TestHashTable_makeHash(t *testing.T) {
 	tests := []testCase[int, *people]{
 		{
 			name:    "got value till size",
-			want:    uint64(132),
+			want:    uint64(238),
 			wantErr: assert.NoError,
 			ht: func() *HashTable[int, *people] {
 				t, _ := NewHashTableWithCapacity[int, *people](uint64(150))
@@ -118,7 +118,7 @@ func TestHashTable_makeHash(t *testing.T) {
 		{
 			name:    "got value more than size",
 			args:    500,
-			want:    uint64(149),
+			want:    uint64(181),
 			wantErr: assert.NoError,
 			ht: func() *HashTable[int, *people] {
 				t, _ := NewHashTableWithCapacity[int, *people](uint64(150))
@@ -128,7 +128,7 @@ func TestHashTable_makeHash(t *testing.T) {
 		{
 			name:    "got value == size",
 			args:    150,
-			want:    uint64(5),
+			want:    uint64(103),
 			wantErr: assert.NoError,
 			ht: func() *HashTable[int, *people] {
 				t, _ := NewHashTableWithCapacity[int, *people](uint64(150))
@@ -138,12 +138,12 @@ func TestHashTable_makeHash(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := tt.ht.hasher.makeHash(tt.args)
-			if !tt.wantErr(t, err, fmt.Sprintf("makeHash(%v)", tt.args)) {
+			got, err := tt.ht.index(tt.args)
+			if !tt.wantErr(t, err, fmt.Sprintf("index(%v)", tt.args)) {
 				return
 			}
-			assert.Equalf(t, tt.want, got, "makeHash(%v)", tt.args)
-			assert.Less(t, got, tt.ht.capacity, "makeHash(%v)", tt.args)
+			assert.Equalf(t, tt.want, got, "index(%v)", tt.args)
+			assert.Less(t, got, tt.ht.capacity, "index(%v)", tt.args)
 		})
 	}
 }
@@ -168,11 +168,11 @@ func TestHashTable_Put(t *testing.T) {
 	tests := []testCase[int, *people]{
 		{
 			name: "got error by hasher",
-			args: args[int, *people]{key: 2, val: &people{name: "test", age: 1}},
+			args: args[int, *people]{key: 453, val: &people{name: "test", age: 1}},
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
 				h := MockHasher[int]{}
-				h.On("makeHash", mock.AnythingOfType("int")).
+				h.On("Hash", mock.AnythingOfType("int")).
 					Return(uint64(0), errors.New("test err"))
 				mt.hasher = &h
 				return mt
@@ -184,7 +184,7 @@ func TestHashTable_Put(t *testing.T) {
 		},
 		{
 			name: "put without collision",
-			args: args[int, *people]{key: 2, val: &people{name: "test", age: 1}},
+			args: args[int, *people]{key: 453, val: &people{name: "test", age: 1}},
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
 				return mt
@@ -198,16 +198,16 @@ func TestHashTable_Put(t *testing.T) {
 		},
 		{
 			name: "put with collision",
-			args: args[int, *people]{key: 2, val: &people{name: "test", age: 2}},
+			args: args[int, *people]{key: 453, val: &people{name: "test", age: 453}},
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				mt.table[132] = mt.newNode(0, &people{name: "test", age: 0})
+				mt.table[238] = mt.newNode(0, &people{name: "test", age: 0})
 				mt.size++
 				return mt
 			}(),
 			check: true,
 			want: want[*people]{
-				val:     &people{name: "test", age: 2},
+				val:     &people{name: "test", age: 453},
 				wantErr: assert.NoError,
 				size:    2,
 			},
@@ -218,7 +218,7 @@ func TestHashTable_Put(t *testing.T) {
 			tt.want.wantErr(t, tt.ht.Put(tt.args.key, tt.args.val), fmt.Sprintf("Put(%v, %v)", tt.args.key, tt.args.val))
 			if tt.check {
 				assert.Equalf(t, tt.want.size, tt.ht.size, "Put(%v, %v) size", tt.args.key, tt.args.val)
-				i, _ := tt.ht.hasher.makeHash(tt.args.key)
+				i, _ := tt.ht.index(tt.args.key)
 				assert.Equalf(t, *tt.want.val, *iterateThrowCollision(tt.args.key, tt.ht.table[i]), "Put(%v, %v) value", tt.args.key, tt.args.val)
 			}
 		})
@@ -252,7 +252,7 @@ func TestHashTable_Contains(t *testing.T) {
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
 				h := MockHasher[int]{}
-				h.On("makeHash", mock.AnythingOfType("int")).
+				h.On("Hash", mock.AnythingOfType("int")).
 					Return(uint64(0), errors.New("test err"))
 				mt.hasher = &h
 				return mt
@@ -276,7 +276,7 @@ func TestHashTable_Contains(t *testing.T) {
 			wantErr: assert.NoError,
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				mt.table[132] = &node[int, *people]{key: 2, value: &people{name: "test", age: 2}}
+				mt.table[238] = &node[int, *people]{key: 453, value: &people{name: "test", age: 453}}
 				return mt
 			}(),
 			check: true,
@@ -288,9 +288,9 @@ func TestHashTable_Contains(t *testing.T) {
 			wantErr: assert.NoError,
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				mt.table[132] = &node[int, *people]{
-					key:   2,
-					value: &people{name: "test", age: 2},
+				mt.table[238] = &node[int, *people]{
+					key:   453,
+					value: &people{name: "test", age: 453},
 					next:  &node[int, *people]{key: 0, value: &people{age: 0, name: "test"}},
 				}
 				return mt
@@ -325,7 +325,7 @@ func TestHashTable_Remove(t *testing.T) {
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
 				h := MockHasher[int]{}
-				h.On("makeHash", mock.AnythingOfType("int")).
+				h.On("Hash", mock.AnythingOfType("int")).
 					Return(uint64(0), errors.New("test err"))
 				mt.hasher = &h
 				return mt
@@ -344,20 +344,20 @@ func TestHashTable_Remove(t *testing.T) {
 			name: "got error when not find",
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				mt.table[132] = &node[int, *people]{key: 0, value: &people{name: "test", age: 0}}
+				mt.table[238] = &node[int, *people]{key: 0, value: &people{name: "test", age: 0}}
 				return mt
 			}(),
-			args:    2,
+			args:    453,
 			wantErr: assert.Error,
 		},
 		{
 			name: "remove without collision",
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				mt.table[132] = &node[int, *people]{key: 44, value: &people{name: "test", age: 44}}
+				mt.table[238] = &node[int, *people]{key: 728, value: &people{name: "test", age: 728}}
 				return mt
 			}(),
-			args:    44,
+			args:    728,
 			wantErr: assert.NoError,
 			check:   true,
 		},
@@ -365,13 +365,13 @@ func TestHashTable_Remove(t *testing.T) {
 			name: "remove with collision in tail",
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				mt.table[132] = &node[int, *people]{key: 44, value: &people{name: "test", age: 44}}
-				mt.resolvePutCollision(8, &people{name: "test", age: 8}, 132)
-				mt.resolvePutCollision(2, &people{name: "test", age: 2}, 132)
-				mt.resolvePutCollision(0, &people{name: "test", age: 0}, 132)
+				mt.table[238] = &node[int, *people]{key: 728, value: &people{name: "test", age: 728}}
+				mt.resolvePutCollision(536, &people{name: "test", age: 536}, 238)
+				mt.resolvePutCollision(453, &people{name: "test", age: 453}, 238)
+				mt.resolvePutCollision(0, &people{name: "test", age: 0}, 238)
 				return mt
 			}(),
-			args:    44,
+			args:    728,
 			wantErr: assert.NoError,
 			check:   true,
 		},
@@ -379,10 +379,10 @@ func TestHashTable_Remove(t *testing.T) {
 			name: "remove with collision in head",
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				mt.table[132] = &node[int, *people]{key: 44, value: &people{name: "test", age: 44}}
-				mt.resolvePutCollision(8, &people{name: "test", age: 8}, 132)
-				mt.resolvePutCollision(2, &people{name: "test", age: 2}, 132)
-				mt.resolvePutCollision(0, &people{name: "test", age: 0}, 132)
+				mt.table[238] = &node[int, *people]{key: 728, value: &people{name: "test", age: 728}}
+				mt.resolvePutCollision(536, &people{name: "test", age: 536}, 238)
+				mt.resolvePutCollision(453, &people{name: "test", age: 453}, 238)
+				mt.resolvePutCollision(0, &people{name: "test", age: 0}, 238)
 				return mt
 			}(),
 			args:    0,
@@ -393,13 +393,13 @@ func TestHashTable_Remove(t *testing.T) {
 			name: "remove with collision in middle",
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				mt.table[132] = &node[int, *people]{key: 44, value: &people{name: "test", age: 44}}
-				mt.resolvePutCollision(8, &people{name: "test", age: 8}, 132)
-				mt.resolvePutCollision(2, &people{name: "test", age: 2}, 132)
-				mt.resolvePutCollision(0, &people{name: "test", age: 0}, 132)
+				mt.table[238] = &node[int, *people]{key: 728, value: &people{name: "test", age: 728}}
+				mt.resolvePutCollision(536, &people{name: "test", age: 536}, 238)
+				mt.resolvePutCollision(453, &people{name: "test", age: 453}, 238)
+				mt.resolvePutCollision(0, &people{name: "test", age: 0}, 238)
 				return mt
 			}(),
-			args:    2,
+			args:    453,
 			wantErr: assert.NoError,
 			check:   true,
 		},
@@ -408,7 +408,7 @@ func TestHashTable_Remove(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.wantErr(t, tt.ht.Remove(tt.args), fmt.Sprintf("Remove(%v)", tt.args))
 			if tt.check {
-				i, _ := tt.ht.hasher.makeHash(tt.args)
+				i, _ := tt.ht.index(tt.args)
 				assert.Equal(t, new(people), iterateThrowCollision(tt.args, tt.ht.table[i]))
 			}
 		})
@@ -432,10 +432,10 @@ func TestHashTable_Clear(t *testing.T) {
 			name: "not empty",
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				mt.table[132] = &node[int, *people]{
-					key:   8,
-					value: &people{name: "test", age: 8},
-					next:  &node[int, *people]{key: 44, value: &people{name: "test", age: 44}},
+				mt.table[238] = &node[int, *people]{
+					key:   536,
+					value: &people{name: "test", age: 536},
+					next:  &node[int, *people]{key: 728, value: &people{name: "test", age: 728}},
 				}
 				mt.table[0] = &node[int, *people]{
 					key:   99,
@@ -481,14 +481,14 @@ func TestHashTable_Keys(t *testing.T) {
 			name: "got keys",
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				mt.table[132] = &node[int, *people]{key: 44, value: &people{name: "test", age: 44}}
-				mt.resolvePutCollision(8, &people{name: "test", age: 8}, 132)
-				mt.resolvePutCollision(2, &people{name: "test", age: 2}, 132)
-				mt.resolvePutCollision(0, &people{name: "test", age: 0}, 132)
+				mt.table[238] = &node[int, *people]{key: 728, value: &people{name: "test", age: 728}}
+				mt.resolvePutCollision(536, &people{name: "test", age: 536}, 238)
+				mt.resolvePutCollision(453, &people{name: "test", age: 453}, 238)
+				mt.resolvePutCollision(0, &people{name: "test", age: 0}, 238)
 				mt.size = 4
 				return mt
 			}(),
-			want: []int{8, 44, 0, 2},
+			want: []int{536, 728, 0, 453},
 		},
 	}
 	for _, tt := range tests {
@@ -517,17 +517,17 @@ func TestHashTable_Values(t *testing.T) {
 			name: "got keys",
 			ht: func() *HashTable[int, *people] {
 				mt, _ := NewHashTableWithCapacity[int, *people](uint64(150))
-				mt.table[132] = &node[int, *people]{key: 44, value: &people{name: "test", age: 44}}
-				mt.resolvePutCollision(8, &people{name: "test", age: 8}, 132)
-				mt.resolvePutCollision(2, &people{name: "test", age: 2}, 132)
-				mt.resolvePutCollision(0, &people{name: "test", age: 0}, 132)
+				mt.table[238] = &node[int, *people]{key: 728, value: &people{name: "test", age: 728}}
+				mt.resolvePutCollision(536, &people{name: "test", age: 536}, 238)
+				mt.resolvePutCollision(453, &people{name: "test", age: 453}, 238)
+				mt.resolvePutCollision(0, &people{name: "test", age: 0}, 238)
 				mt.size = 4
 				return mt
 			}(),
 			want: []*people{
-				{name: "test", age: 44},
-				{name: "test", age: 8},
-				{name: "test", age: 2},
+				{name: "test", age: 728},
+				{name: "test", age: 536},
+				{name: "test", age: 453},
 				{name: "test", age: 0},
 			},
 		},
@@ -538,3 +538,233 @@ func TestHashTable_Values(t *testing.T) {
 		})
 	}
 }
+
+func TestHashTable_SetLoadFactors(t *testing.T) {
+	type args struct {
+		low  float64
+		high float64
+	}
+	type testCase struct {
+		name    string
+		args    args
+		wantErr assert.ErrorAssertionFunc
+	}
+	tests := []testCase{
+		{name: "negative low", args: args{low: -0.1, high: 0.75}, wantErr: assert.Error},
+		{name: "zero high", args: args{low: 0, high: 0}, wantErr: assert.Error},
+		{name: "low too close to high", args: args{low: 0.5, high: 0.75}, wantErr: assert.Error},
+		{name: "valid", args: args{low: 0.1, high: 0.9}, wantErr: assert.NoError},
+		{name: "disable shrink", args: args{low: 0, high: 2}, wantErr: assert.NoError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ht := NewHashTable[int, int]()
+			err := ht.SetLoadFactors(tt.args.low, tt.args.high)
+			tt.wantErr(t, err, fmt.Sprintf("SetLoadFactors(%v, %v)", tt.args.low, tt.args.high))
+			if err != nil {
+				assert.ErrorIs(t, err, ErrInvalidLoadFactor)
+				assert.Equal(t, defaultLowLoadFactor, ht.lowLoadFactor)
+				assert.Equal(t, defaultHighLoadFactor, ht.highLoadFactor)
+				return
+			}
+			assert.Equal(t, tt.args.low, ht.lowLoadFactor)
+			assert.Equal(t, tt.args.high, ht.highLoadFactor)
+		})
+	}
+}
+
+func TestHashTable_Rehash(t *testing.T) {
+	type testCase struct {
+		name     string
+		ht       *HashTable[int, int]
+		capacity uint64
+		wantErr  assert.ErrorAssertionFunc
+	}
+	tests := []testCase{
+		{
+			name:     "got error when capacity is empty",
+			ht:       NewHashTable[int, int](),
+			capacity: 0,
+			wantErr:  assert.Error,
+		},
+		{
+			name: "got error by hasher",
+			ht: func() *HashTable[int, int] {
+				mt := NewHashTable[int, int]()
+				mt.table[0] = &node[int, int]{key: 1, value: 1}
+				mt.size = 1
+				h := MockHasher[int]{}
+				h.On("Hash", mock.AnythingOfType("int")).
+					Return(uint64(0), errors.New("test err"))
+				mt.hasher = &h
+				return mt
+			}(),
+			capacity: 64,
+			wantErr:  assert.Error,
+		},
+		{
+			name: "grow",
+			ht: func() *HashTable[int, int] {
+				mt := NewHashTable[int, int]()
+				for i := 0; i < 10; i++ {
+					_ = mt.Put(i, i*i)
+				}
+				return mt
+			}(),
+			capacity: 128,
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "shrink",
+			ht: func() *HashTable[int, int] {
+				mt, _ := NewHashTableWithCapacity[int, int](256)
+				for i := 0; i < 10; i++ {
+					_ = mt.Put(i, i*i)
+				}
+				return mt
+			}(),
+			capacity: 32,
+			wantErr:  assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldCapacity := tt.ht.Capacity()
+			keys := tt.ht.Keys()
+			if !tt.wantErr(t, tt.ht.Rehash(tt.capacity), fmt.Sprintf("Rehash(%v)", tt.capacity)) {
+				return
+			}
+			if tt.capacity < 1 {
+				assert.Equal(t, oldCapacity, tt.ht.Capacity())
+				return
+			}
+			if _, ok := tt.ht.hasher.(*MockHasher[int]); ok {
+				assert.Equal(t, oldCapacity, tt.ht.Capacity())
+				return
+			}
+			assert.Equal(t, tt.capacity, tt.ht.Capacity())
+			assert.Len(t, tt.ht.table, int(tt.capacity))
+			for _, k := range keys {
+				got, err := tt.ht.Get(k)
+				assert.NoError(t, err)
+				assert.Equal(t, k*k, got)
+			}
+		})
+	}
+}
+
+func TestHashTable_AutoResize(t *testing.T) {
+	ht := NewHashTable[int, int]()
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, ht.Put(i, i))
+		assert.LessOrEqual(t, ht.LoadFactor(), ht.highLoadFactor, "Put(%v)", i)
+	}
+	assert.Equal(t, uint64(256), ht.Capacity())
+	assert.Equal(t, uint64(100), ht.Size())
+
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, ht.Remove(i))
+		if ht.Capacity() > defaultCapacity {
+			assert.GreaterOrEqual(t, ht.LoadFactor(), ht.lowLoadFactor, "Remove(%v)", i)
+		}
+	}
+	assert.Equal(t, defaultCapacity, ht.Capacity())
+	assert.True(t, ht.IsEmpty())
+}
+
+func TestKeyNotFoundError(t *testing.T) {
+	hashErr := errors.New("test err")
+	failing := func() *HashTable[int, int] {
+		mt := NewHashTable[int, int]()
+		h := MockHasher[int]{}
+		h.On("Hash", mock.AnythingOfType("int")).
+			Return(uint64(0), hashErr)
+		mt.hasher = &h
+		return mt
+	}
+	type testCase struct {
+		name      string
+		call      func(ht *HashTable[int, int]) error
+		ht        *HashTable[int, int]
+		wantCause error
+	}
+	tests := []testCase{
+		{
+			name: "get missing",
+			call: func(ht *HashTable[int, int]) error { _, err := ht.Get(7); return err },
+			ht:   NewHashTable[int, int](),
+		},
+		{
+			name: "get missing in bucket",
+			call: func(ht *HashTable[int, int]) error { _, err := ht.Get(7); return err },
+			ht: func() *HashTable[int, int] {
+				mt, _ := NewHashTableWithCapacity[int, int](1)
+				_ = mt.SetLoadFactors(0, 10)
+				_ = mt.Put(1, 1)
+				return mt
+			}(),
+		},
+		{
+			name: "remove missing",
+			call: func(ht *HashTable[int, int]) error { return ht.Remove(7) },
+			ht:   NewHashTable[int, int](),
+		},
+		{
+			name:      "get by failed hasher",
+			call:      func(ht *HashTable[int, int]) error { _, err := ht.Get(7); return err },
+			ht:        failing(),
+			wantCause: hashErr,
+		},
+		{
+			name:      "contains by failed hasher",
+			call:      func(ht *HashTable[int, int]) error { _, err := ht.Contains(7); return err },
+			ht:        failing(),
+			wantCause: hashErr,
+		},
+		{
+			name:      "remove by failed hasher",
+			call:      func(ht *HashTable[int, int]) error { return ht.Remove(7) },
+			ht:        failing(),
+			wantCause: hashErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(tt.ht)
+			assert.ErrorIs(t, err, ErrElementIsEmptyByKey)
+			var notFound *KeyNotFoundError[int]
+			if assert.ErrorAs(t, err, &notFound) {
+				assert.Equal(t, 7, notFound.Key)
+				assert.Equal(t, tt.wantCause, notFound.Err)
+			}
+			if tt.wantCause != nil {
+				assert.ErrorIs(t, err, tt.wantCause)
+				assert.EqualError(t, err, "cannot find element by key 7: test err")
+			} else {
+				assert.EqualError(t, err, "cannot find element by key 7")
+			}
+		})
+	}
+}
+
+func TestHashTable_Lookup(t *testing.T) {
+	ht := NewHashTable[string, int](WithHasher[string](StringHasher[string]{}))
+	_ = ht.Put("a", 1)
+
+	got, ok := ht.Lookup("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, got)
+
+	got, ok = ht.Lookup("b")
+	assert.False(t, ok)
+	assert.Zero(t, got)
+	assert.Zero(t, testing.AllocsPerRun(100, func() { ht.Lookup("b") }))
+
+	failing := NewHashTable[int, int]()
+	h := MockHasher[int]{}
+	h.On("Hash", mock.AnythingOfType("int")).
+		Return(uint64(0), errors.New("test err"))
+	failing.hasher = &h
+	_, ok = failing.Lookup(1)
+	assert.False(t, ok)
+}
@@ -0,0 +1,97 @@
+package hash_table
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+const (
+	fnvOffset64 uint64 = 14695981039346656037
+	fnvPrime64  uint64 = 1099511628211
+)
+
+type Hasher[K comparable] interface {
+	Hash(key K) (uint64, error)
+}
+
+// FNVHasher works for any key but formats it with fmt.Sprintf first
+type FNVHasher[K comparable] struct{}
+
+func (FNVHasher[K]) Hash(key K) (uint64, error) {
+	hs := fnv.New64()
+	if _, err := hs.Write([]byte(fmt.Sprintf("%v", key))); err != nil {
+		return 0, err
+	}
+	return hs.Sum64(), nil
+}
+
+// StringHasher gives the same hash as FNVHasher without allocations
+type StringHasher[K ~string] struct{}
+
+func (StringHasher[K]) Hash(key K) (uint64, error) {
+	h := fnvOffset64
+	for i := 0; i < len(key); i++ {
+		h *= fnvPrime64
+		h ^= uint64(key[i])
+	}
+	return h, nil
+}
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type IntegerHasher[K Integer] struct{}
+
+func (IntegerHasher[K]) Hash(key K) (uint64, error) {
+	return Mix(uint64(key)), nil
+}
+
+// BytesHasher hashes the byte representation of a key, e.g. k[:] for [16]byte keys
+type BytesHasher[K comparable] func(key K) []byte
+
+func (b BytesHasher[K]) Hash(key K) (uint64, error) {
+	return hashBytes(b(key)), nil
+}
+
+type SeededHasher[K comparable] struct {
+	seed   uint64
+	hasher Hasher[K]
+}
+
+func (s *SeededHasher[K]) Hash(key K) (uint64, error) {
+	h, err := s.hasher.Hash(key)
+	if err != nil {
+		return 0, err
+	}
+	return Mix(h ^ s.seed), nil
+}
+
+func NewSeededHasher[K comparable](seed uint64, hasher Hasher[K]) *SeededHasher[K] {
+	if hasher == nil {
+		hasher = FNVHasher[K]{}
+	}
+	return &SeededHasher[K]{seed: seed, hasher: hasher}
+}
+
+func hashBytes(b []byte) uint64 {
+	h := fnvOffset64
+	for _, c := range b {
+		h *= fnvPrime64
+		h ^= uint64(c)
+	}
+	return h
+}
+
+// Mix is the splitmix64 finalizer, it spreads close integers over all bits.
+// FNV hashes of short or similar keys differ mostly in their low bits,
+// so code which takes high bits of a hash or adds offsets to it should Mix it first
+func Mix(h uint64) uint64 {
+	h ^= h >> 30
+	h *= 0xbf58476d1ce4e5b9
+	h ^= h >> 27
+	h *= 0x94d049bb133111eb
+	h ^= h >> 31
+	return h
+}
@@ -0,0 +1,98 @@
+package hash_table
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStringHasher_Hash(t *testing.T) {
+	tests := []string{"", "a", "hello", "hash table"}
+	for _, key := range tests {
+		t.Run(key, func(t *testing.T) {
+			want, _ := FNVHasher[string]{}.Hash(key)
+			got, err := StringHasher[string]{}.Hash(key)
+			assert.NoError(t, err)
+			assert.Equalf(t, want, got, "Hash(%v)", key)
+		})
+	}
+}
+
+func TestHasher_Distribution(t *testing.T) {
+	type testCase struct {
+		name   string
+		hasher Hasher[int]
+	}
+	tests := []testCase{
+		{name: "fnv", hasher: FNVHasher[int]{}},
+		{name: "integer", hasher: IntegerHasher[int]{}},
+		{name: "seeded", hasher: NewSeededHasher[int](42, IntegerHasher[int]{})},
+		{name: "bytes", hasher: BytesHasher[int](func(key int) []byte { return []byte{byte(key), byte(key >> 8)} })},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make(map[uint64]struct{})
+			for i := 0; i < 1000; i++ {
+				h, err := tt.hasher.Hash(i)
+				assert.NoError(t, err)
+				seen[h] = struct{}{}
+				again, _ := tt.hasher.Hash(i)
+				assert.Equalf(t, h, again, "Hash(%v) is not deterministic", i)
+			}
+			assert.Len(t, seen, 1000)
+		})
+	}
+}
+
+func TestSeededHasher_Hash(t *testing.T) {
+	first := NewSeededHasher[string](1, StringHasher[string]{})
+	second := NewSeededHasher[string](2, StringHasher[string]{})
+	h1, err := first.Hash("key")
+	assert.NoError(t, err)
+	h2, err := second.Hash("key")
+	assert.NoError(t, err)
+	assert.NotEqual(t, h1, h2)
+
+	withDefault := NewSeededHasher[string](1, nil)
+	h3, err := withDefault.Hash("key")
+	assert.NoError(t, err)
+	assert.Equal(t, h1, h3)
+}
+
+func TestHasher_NoAllocs(t *testing.T) {
+	s := StringHasher[string]{}
+	i := IntegerHasher[uint32]{}
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _, _ = s.Hash("no allocations") }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { _, _ = i.Hash(42) }))
+}
+
+func TestNewHashTable_WithHasher(t *testing.T) {
+	ht := NewHashTable[string, int](WithHasher[string](StringHasher[string]{}))
+	assert.IsType(t, StringHasher[string]{}, ht.hasher)
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, ht.Put(fmt.Sprintf("key%d", i), i))
+	}
+	for i := 0; i < 100; i++ {
+		got, err := ht.Get(fmt.Sprintf("key%d", i))
+		assert.NoError(t, err)
+		assert.Equal(t, i, got)
+	}
+
+	withNil := NewHashTable[string, int](WithHasher[string](nil))
+	assert.IsType(t, FNVHasher[string]{}, withNil.hasher)
+}
+
+func BenchmarkHasher(b *testing.B) {
+	b.Run("fnv", func(b *testing.B) {
+		h := FNVHasher[string]{}
+		for i := 0; i < b.N; i++ {
+			_, _ = h.Hash("benchmark key")
+		}
+	})
+	b.Run("string", func(b *testing.B) {
+		h := StringHasher[string]{}
+		for i := 0; i < b.N; i++ {
+			_, _ = h.Hash("benchmark key")
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package hash_table
+
+import (
+	"errors"
+	"iter"
+)
+
+var ErrConcurrentModification = errors.New("hash table was modified during iteration")
+
+// HashTableEntryFunc stops the iteration when it returns false
+type HashTableEntryFunc[K comparable, T any] func(key K, val T) bool
+
+// Range calls f for every entry until f returns false.
+// Updating values of existing keys is allowed, any insert, remove or rehash
+// made by f stops the iteration with ErrConcurrentModification
+func (t *HashTable[K, T]) Range(f HashTableEntryFunc[K, T]) error {
+	modCount := t.modCount
+	for _, el := range t.table {
+		for v := el; v != nil; v = v.next {
+			if !f(v.key, v.value) {
+				return nil
+			}
+			if t.modCount != modCount {
+				return ErrConcurrentModification
+			}
+		}
+	}
+	return nil
+}
+
+// All panics with ErrConcurrentModification when the loop body changes the table structure
+func (t *HashTable[K, T]) All() iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		if err := t.Range(yield); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (t *HashTable[K, T]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range t.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+func (t *HashTable[K, T]) ValuesSeq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, val := range t.All() {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package hash_table
+
+import (
+	"github.com/stretchr/testify/assert"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func fetchHashTable(counter int) *HashTable[int, int] {
+	ht := NewHashTable[int, int]()
+	for i := 0; i < counter; i++ {
+		_ = ht.Put(i, i*10)
+	}
+	return ht
+}
+
+func TestHashTable_Range(t *testing.T) {
+	type testCase struct {
+		name    string
+		ht      *HashTable[int, int]
+		f       func(ht *HashTable[int, int], seen map[int]int) HashTableEntryFunc[int, int]
+		want    int
+		wantErr error
+	}
+	tests := []testCase{
+		{
+			name: "when empty",
+			ht:   fetchHashTable(0),
+			f: func(ht *HashTable[int, int], seen map[int]int) HashTableEntryFunc[int, int] {
+				return func(key int, val int) bool { seen[key] = val; return true }
+			},
+			want: 0,
+		},
+		{
+			name: "all entries",
+			ht:   fetchHashTable(50),
+			f: func(ht *HashTable[int, int], seen map[int]int) HashTableEntryFunc[int, int] {
+				return func(key int, val int) bool { seen[key] = val; return true }
+			},
+			want: 50,
+		},
+		{
+			name: "early termination",
+			ht:   fetchHashTable(50),
+			f: func(ht *HashTable[int, int], seen map[int]int) HashTableEntryFunc[int, int] {
+				return func(key int, val int) bool { seen[key] = val; return len(seen) < 5 }
+			},
+			want: 5,
+		},
+		{
+			name: "update value is allowed",
+			ht:   fetchHashTable(50),
+			f: func(ht *HashTable[int, int], seen map[int]int) HashTableEntryFunc[int, int] {
+				return func(key int, val int) bool { seen[key] = val; return ht.Put(key, val+1) == nil }
+			},
+			want: 50,
+		},
+		{
+			name: "put during iteration",
+			ht:   fetchHashTable(50),
+			f: func(ht *HashTable[int, int], seen map[int]int) HashTableEntryFunc[int, int] {
+				return func(key int, val int) bool { seen[key] = val; return ht.Put(key+1000, val) == nil }
+			},
+			want:    1,
+			wantErr: ErrConcurrentModification,
+		},
+		{
+			name: "remove during iteration",
+			ht:   fetchHashTable(50),
+			f: func(ht *HashTable[int, int], seen map[int]int) HashTableEntryFunc[int, int] {
+				return func(key int, val int) bool { seen[key] = val; return ht.Remove(key) == nil }
+			},
+			want:    1,
+			wantErr: ErrConcurrentModification,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := make(map[int]int)
+			err := tt.ht.Range(tt.f(tt.ht, seen))
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Len(t, seen, tt.want)
+			for k, v := range seen {
+				assert.Equal(t, k*10, v)
+			}
+		})
+	}
+}
+
+func TestHashTable_All(t *testing.T) {
+	ht := fetchHashTable(50)
+	got := maps.Collect(ht.All())
+	assert.Len(t, got, 50)
+	for k, v := range got {
+		assert.Equal(t, k*10, v)
+	}
+
+	var count int
+	for range ht.All() {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	assert.Equal(t, 3, count)
+
+	assert.PanicsWithError(t, ErrConcurrentModification.Error(), func() {
+		for k := range ht.All() {
+			_ = ht.Remove(k)
+		}
+	})
+}
+
+func TestHashTable_KeysSeq(t *testing.T) {
+	ht := fetchHashTable(20)
+	assert.ElementsMatch(t, ht.Keys(), slices.Collect(ht.KeysSeq()))
+	assert.Empty(t, slices.Collect(fetchHashTable(0).KeysSeq()))
+}
+
+func TestHashTable_ValuesSeq(t *testing.T) {
+	ht := fetchHashTable(20)
+	assert.ElementsMatch(t, ht.Values(), slices.Collect(ht.ValuesSeq()))
+	var found bool
+	for v := range ht.ValuesSeq() {
+		if v == 50 {
+			found = true
+			break
+		}
+	}
+	assert.True(t, found)
+}
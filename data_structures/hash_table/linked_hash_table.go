@@ -0,0 +1,218 @@
+package hash_table
+
+import (
+	"algoritms_and_structures/data_structures/lists"
+	"iter"
+)
+
+type Order uint8
+
+const (
+	InsertionOrder Order = iota
+	// AccessOrder moves an entry to the back on every Get and Put
+	AccessOrder
+)
+
+type linkedEntry[K comparable, T any] struct {
+	key   K
+	value T
+}
+
+// LinkedHashTable iterates from the oldest entry at the front to the newest at the back
+type LinkedHashTable[K comparable, T any] struct {
+	order    Order
+	modCount uint64
+	table    *HashTable[K, *lists.DoubleNode[*linkedEntry[K, T]]]
+	list     *lists.DoubleLinkedList[*linkedEntry[K, T]]
+}
+
+func (t *LinkedHashTable[K, T]) Size() uint64 {
+	return t.table.Size()
+}
+
+func (t *LinkedHashTable[K, T]) IsEmpty() bool {
+	return t.table.IsEmpty()
+}
+
+func (t *LinkedHashTable[K, T]) Order() Order {
+	return t.order
+}
+
+func (t *LinkedHashTable[K, T]) touch(n *lists.DoubleNode[*linkedEntry[K, T]]) {
+	if t.order == AccessOrder && n != t.list.Tail() {
+		_ = t.list.MoveToTail(n)
+		t.modCount++
+	}
+}
+
+func (t *LinkedHashTable[K, T]) Get(key K) (T, error) {
+	n, err := t.table.Get(key)
+	if err != nil {
+		return *new(T), err
+	}
+	t.touch(n)
+	return n.Value().value, nil
+}
+
+func (t *LinkedHashTable[K, T]) Lookup(key K) (T, bool) {
+	n, ok := t.table.Lookup(key)
+	if !ok {
+		return *new(T), false
+	}
+	t.touch(n)
+	return n.Value().value, true
+}
+
+func (t *LinkedHashTable[K, T]) Contains(key K) (bool, error) {
+	return t.table.Contains(key)
+}
+
+// Put keeps the position of an existing key unless the table is access ordered
+func (t *LinkedHashTable[K, T]) Put(key K, val T) error {
+	n, err := t.table.lookup(key)
+	if err != nil {
+		return err
+	}
+	if n != nil {
+		n.value.Value().value = val
+		t.touch(n.value)
+		return nil
+	}
+	node := lists.NewDoubleNode(&linkedEntry[K, T]{key: key, value: val})
+	// a failed grow still keeps the key, so the list follows what the table did
+	stored, err := t.table.put(key, node)
+	if stored {
+		_ = t.list.LinkTail(node)
+		t.modCount++
+	}
+	return err
+}
+
+func (t *LinkedHashTable[K, T]) Remove(key K) error {
+	n, err := t.table.Get(key)
+	if err != nil {
+		return err
+	}
+	removed, err := t.table.remove(key)
+	if removed {
+		_ = t.list.Unlink(n)
+		t.modCount++
+	}
+	return err
+}
+
+func (t *LinkedHashTable[K, T]) move(key K, toFront bool) error {
+	n, err := t.table.Get(key)
+	if err != nil {
+		return err
+	}
+	if toFront {
+		err = t.list.MoveToHead(n)
+	} else {
+		err = t.list.MoveToTail(n)
+	}
+	t.modCount++
+	return err
+}
+
+func (t *LinkedHashTable[K, T]) MoveToFront(key K) error {
+	return t.move(key, true)
+}
+
+func (t *LinkedHashTable[K, T]) MoveToBack(key K) error {
+	return t.move(key, false)
+}
+
+func (t *LinkedHashTable[K, T]) Oldest() (K, T, bool) {
+	head := t.list.Head()
+	if head == nil {
+		return *new(K), *new(T), false
+	}
+	return head.Value().key, head.Value().value, true
+}
+
+func (t *LinkedHashTable[K, T]) Newest() (K, T, bool) {
+	tail := t.list.Tail()
+	if tail == nil {
+		return *new(K), *new(T), false
+	}
+	return tail.Value().key, tail.Value().value, true
+}
+
+func (t *LinkedHashTable[K, T]) Clear() {
+	t.table.Clear()
+	t.list = lists.NewDoubleLinkedList[*linkedEntry[K, T]]()
+	t.modCount++
+}
+
+// Range walks entries in order, see HashTable.Range for the modification rules
+func (t *LinkedHashTable[K, T]) Range(f HashTableEntryFunc[K, T]) error {
+	modCount := t.modCount
+	for n := t.list.Head(); n != nil; n = n.Next() {
+		if !f(n.Value().key, n.Value().value) {
+			return nil
+		}
+		if t.modCount != modCount {
+			return ErrConcurrentModification
+		}
+	}
+	return nil
+}
+
+func (t *LinkedHashTable[K, T]) All() iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		if err := t.Range(yield); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (t *LinkedHashTable[K, T]) KeysSeq() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range t.All() {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+func (t *LinkedHashTable[K, T]) ValuesSeq() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, val := range t.All() {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+func (t *LinkedHashTable[K, T]) Foreach(tFunc HashTableFunc[T]) {
+	for n := t.list.Head(); n != nil; n = n.Next() {
+		tFunc(n.Value().value)
+	}
+}
+
+func (t *LinkedHashTable[K, T]) Keys() []K {
+	var keys []K
+	for n := t.list.Head(); n != nil; n = n.Next() {
+		keys = append(keys, n.Value().key)
+	}
+	return keys
+}
+
+func (t *LinkedHashTable[K, T]) Values() []T {
+	var values []T
+	for n := t.list.Head(); n != nil; n = n.Next() {
+		values = append(values, n.Value().value)
+	}
+	return values
+}
+
+func NewLinkedHashTable[K comparable, T any](order Order, opts ...Option[K]) *LinkedHashTable[K, T] {
+	return &LinkedHashTable[K, T]{
+		order: order,
+		table: NewHashTable[K, *lists.DoubleNode[*linkedEntry[K, T]]](opts...),
+		list:  lists.NewDoubleLinkedList[*linkedEntry[K, T]](),
+	}
+}
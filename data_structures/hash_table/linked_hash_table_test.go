@@ -0,0 +1,176 @@
+package hash_table
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"slices"
+	"testing"
+)
+
+func fetchLinkedHashTable(order Order, keys ...string) *LinkedHashTable[string, int] {
+	lt := NewLinkedHashTable[string, int](order)
+	for i, k := range keys {
+		_ = lt.Put(k, i)
+	}
+	return lt
+}
+
+func TestLinkedHashTable_Order(t *testing.T) {
+	type testCase struct {
+		name    string
+		order   Order
+		actions func(lt *LinkedHashTable[string, int])
+		want    []string
+	}
+	tests := []testCase{
+		{
+			name:    "insertion order",
+			order:   InsertionOrder,
+			actions: func(lt *LinkedHashTable[string, int]) {},
+			want:    []string{"c", "a", "d", "b"},
+		},
+		{
+			name:  "insertion order ignores update and get",
+			order: InsertionOrder,
+			actions: func(lt *LinkedHashTable[string, int]) {
+				_ = lt.Put("c", 100)
+				_, _ = lt.Get("a")
+			},
+			want: []string{"c", "a", "d", "b"},
+		},
+		{
+			name:  "access order moves on update and get",
+			order: AccessOrder,
+			actions: func(lt *LinkedHashTable[string, int]) {
+				_ = lt.Put("c", 100)
+				_, _ = lt.Get("a")
+				lt.Lookup("d")
+			},
+			want: []string{"b", "c", "a", "d"},
+		},
+		{
+			name:  "remove and put again goes to back",
+			order: InsertionOrder,
+			actions: func(lt *LinkedHashTable[string, int]) {
+				_ = lt.Remove("c")
+				_ = lt.Put("c", 0)
+			},
+			want: []string{"a", "d", "b", "c"},
+		},
+		{
+			name:  "move to front and back",
+			order: InsertionOrder,
+			actions: func(lt *LinkedHashTable[string, int]) {
+				_ = lt.MoveToFront("b")
+				_ = lt.MoveToBack("c")
+			},
+			want: []string{"b", "a", "d", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lt := fetchLinkedHashTable(tt.order, "c", "a", "d", "b")
+			tt.actions(lt)
+			assert.Equal(t, tt.want, lt.Keys())
+			assert.Equal(t, tt.want, slices.Collect(lt.KeysSeq()))
+			assert.Equal(t, lt.Values(), slices.Collect(lt.ValuesSeq()))
+			assert.Equal(t, uint64(len(tt.want)), lt.Size())
+		})
+	}
+}
+
+func TestLinkedHashTable_OldestNewest(t *testing.T) {
+	lt := fetchLinkedHashTable(InsertionOrder)
+	_, _, ok := lt.Oldest()
+	assert.False(t, ok)
+	_, _, ok = lt.Newest()
+	assert.False(t, ok)
+
+	lt = fetchLinkedHashTable(InsertionOrder, "a", "b", "c")
+	k, v, ok := lt.Oldest()
+	assert.True(t, ok)
+	assert.Equal(t, "a", k)
+	assert.Equal(t, 0, v)
+	k, v, ok = lt.Newest()
+	assert.True(t, ok)
+	assert.Equal(t, "c", k)
+	assert.Equal(t, 2, v)
+}
+
+func TestLinkedHashTable_Errors(t *testing.T) {
+	lt := fetchLinkedHashTable(InsertionOrder, "a")
+	assert.ErrorIs(t, lt.Remove("b"), ErrElementIsEmptyByKey)
+	assert.ErrorIs(t, lt.MoveToFront("b"), ErrElementIsEmptyByKey)
+	assert.ErrorIs(t, lt.MoveToBack("b"), ErrElementIsEmptyByKey)
+	_, err := lt.Get("b")
+	assert.ErrorIs(t, err, ErrElementIsEmptyByKey)
+	ok, err := lt.Contains("a")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestLinkedHashTable_HasherError(t *testing.T) {
+	lt := fetchLinkedHashTable(InsertionOrder, "a")
+	h := MockHasher[string]{}
+	h.On("Hash", mock.AnythingOfType("string")).
+		Return(uint64(0), errors.New("test err"))
+	lt.table.hasher = &h
+
+	assert.Error(t, lt.Put("b", 1))
+	assert.Error(t, lt.Remove("a"))
+	_, err := lt.Get("a")
+	assert.Error(t, err)
+	assert.Equal(t, uint64(1), lt.Size())
+	assert.Equal(t, []string{"a"}, lt.Keys())
+}
+
+func TestLinkedHashTable_GrowError(t *testing.T) {
+	lt := NewLinkedHashTable[string, int](InsertionOrder)
+	h := MockHasher[string]{}
+	// every Put hashes twice, the 13th one stores its key and then fails to rehash
+	h.On("Hash", mock.AnythingOfType("string")).
+		Return(uint64(0), nil).Times(26)
+	h.On("Hash", mock.AnythingOfType("string")).
+		Return(uint64(0), errors.New("test err"))
+	lt.table.hasher = &h
+
+	var keys []string
+	for i := 0; i < 12; i++ {
+		keys = append(keys, fmt.Sprint(i))
+		assert.NoError(t, lt.Put(keys[i], i))
+	}
+	keys = append(keys, "12")
+	assert.Error(t, lt.Put("12", 12))
+	assert.Equal(t, uint64(13), lt.Size())
+	assert.Equal(t, keys, lt.Keys(), "the order list must follow the table")
+}
+
+func TestLinkedHashTable_Range(t *testing.T) {
+	lt := fetchLinkedHashTable(InsertionOrder, "a", "b", "c")
+	var keys []string
+	assert.NoError(t, lt.Range(func(key string, val int) bool {
+		keys = append(keys, key)
+		return key != "b"
+	}))
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	assert.ErrorIs(t, lt.Range(func(key string, val int) bool {
+		return lt.MoveToBack(key) == nil
+	}), ErrConcurrentModification)
+
+	var values []int
+	lt.Foreach(func(val int) { values = append(values, val) })
+	assert.Equal(t, lt.Values(), values)
+}
+
+func TestLinkedHashTable_Clear(t *testing.T) {
+	lt := fetchLinkedHashTable(InsertionOrder, "a", "b", "c")
+	lt.Clear()
+	assert.True(t, lt.IsEmpty())
+	assert.Nil(t, lt.Keys())
+	_ = lt.Put("d", 1)
+	assert.Equal(t, []string{"d"}, lt.Keys())
+	assert.Equal(t, AccessOrder, NewLinkedHashTable[int, int](AccessOrder).Order())
+}
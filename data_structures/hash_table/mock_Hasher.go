@@ -17,12 +17,12 @@ func (_m *MockHasher[K]) EXPECT() *MockHasher_Expecter[K] {
 	return &MockHasher_Expecter[K]{mock: &_m.Mock}
 }
 
-// makeHash provides a mock function with given fields: key
-func (_m *MockHasher[K]) makeHash(key K) (uint64, error) {
+// Hash provides a mock function with given fields: key
+func (_m *MockHasher[K]) Hash(key K) (uint64, error) {
 	ret := _m.Called(key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for makeHash")
+		panic("no return value specified for Hash")
 	}
 
 	var r0 uint64
@@ -45,30 +45,30 @@ func (_m *MockHasher[K]) makeHash(key K) (uint64, error) {
 	return r0, r1
 }
 
-// MockHasher_makeHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'makeHash'
-type MockHasher_makeHash_Call[K comparable] struct {
+// MockHasher_Hash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Hash'
+type MockHasher_Hash_Call[K comparable] struct {
 	*mock.Call
 }
 
-// makeHash is a helper method to define mock.On call
+// Hash is a helper method to define mock.On call
 //   - key K
-func (_e *MockHasher_Expecter[K]) makeHash(key interface{}) *MockHasher_makeHash_Call[K] {
-	return &MockHasher_makeHash_Call[K]{Call: _e.mock.On("makeHash", key)}
+func (_e *MockHasher_Expecter[K]) Hash(key interface{}) *MockHasher_Hash_Call[K] {
+	return &MockHasher_Hash_Call[K]{Call: _e.mock.On("Hash", key)}
 }
 
-func (_c *MockHasher_makeHash_Call[K]) Run(run func(key K)) *MockHasher_makeHash_Call[K] {
+func (_c *MockHasher_Hash_Call[K]) Run(run func(key K)) *MockHasher_Hash_Call[K] {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(K))
 	})
 	return _c
 }
 
-func (_c *MockHasher_makeHash_Call[K]) Return(_a0 uint64, _a1 error) *MockHasher_makeHash_Call[K] {
+func (_c *MockHasher_Hash_Call[K]) Return(_a0 uint64, _a1 error) *MockHasher_Hash_Call[K] {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockHasher_makeHash_Call[K]) RunAndReturn(run func(K) (uint64, error)) *MockHasher_makeHash_Call[K] {
+func (_c *MockHasher_Hash_Call[K]) RunAndReturn(run func(K) (uint64, error)) *MockHasher_Hash_Call[K] {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,123 @@
+package hash_table
+
+import "errors"
+
+var ErrValueIsNotFound = errors.New("cannot find value by key")
+
+// MultiMap keeps every value put under a key, duplicates included
+type MultiMap[K comparable, V comparable] struct {
+	size  uint64
+	table *HashTable[K, []V]
+}
+
+// Size is the count of all values, KeysCount is the count of distinct keys
+func (m *MultiMap[K, V]) Size() uint64 {
+	return m.size
+}
+
+func (m *MultiMap[K, V]) KeysCount() uint64 {
+	return m.table.Size()
+}
+
+func (m *MultiMap[K, V]) IsEmpty() bool {
+	return m.size < 1
+}
+
+func (m *MultiMap[K, V]) Put(key K, value V) error {
+	n, err := m.table.lookup(key)
+	if err != nil {
+		return err
+	}
+	if n != nil {
+		n.value = append(n.value, value)
+		m.size++
+		return nil
+	}
+	stored, err := m.table.put(key, []V{value})
+	if stored {
+		m.size++
+	}
+	return err
+}
+
+// GetAll returns a copy of the values in insertion order, nil when key is absent
+func (m *MultiMap[K, V]) GetAll(key K) []V {
+	values, ok := m.table.Lookup(key)
+	if !ok {
+		return nil
+	}
+	return append([]V(nil), values...)
+}
+
+func (m *MultiMap[K, V]) Contains(key K) bool {
+	_, ok := m.table.Lookup(key)
+	return ok
+}
+
+func (m *MultiMap[K, V]) ContainsEntry(key K, value V) bool {
+	values, _ := m.table.Lookup(key)
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveValue removes the first occurrence of value, the key goes away with its last value
+func (m *MultiMap[K, V]) RemoveValue(key K, value V) error {
+	n, err := m.table.lookup(key)
+	if err != nil || n == nil {
+		return newKeyNotFoundError(key, err)
+	}
+	for i, v := range n.value {
+		if v != value {
+			continue
+		}
+		if len(n.value) == 1 {
+			removed, err := m.table.remove(key)
+			if removed {
+				m.size--
+			}
+			return err
+		}
+		m.size--
+		n.value = append(n.value[:i], n.value[i+1:]...)
+		return nil
+	}
+	return ErrValueIsNotFound
+}
+
+func (m *MultiMap[K, V]) RemoveAll(key K) ([]V, error) {
+	values, err := m.table.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := m.table.remove(key)
+	if !removed {
+		return nil, err
+	}
+	m.size -= uint64(len(values))
+	return values, err
+}
+
+func (m *MultiMap[K, V]) Keys() []K {
+	return m.table.Keys()
+}
+
+func (m *MultiMap[K, V]) Range(f HashTableEntryFunc[K, V]) error {
+	var stopped bool
+	return m.table.Range(func(key K, values []V) bool {
+		for _, v := range values {
+			if !f(key, v) {
+				stopped = true
+				break
+			}
+		}
+		return !stopped
+	})
+}
+
+func NewMultiMap[K comparable, V comparable](opts ...Option[K]) *MultiMap[K, V] {
+	return &MultiMap[K, V]{table: NewHashTable[K, []V](opts...)}
+}
@@ -0,0 +1,114 @@
+package hash_table
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func fetchMultiMap() *MultiMap[string, int] {
+	m := NewMultiMap[string, int]()
+	_ = m.Put("a", 1)
+	_ = m.Put("a", 2)
+	_ = m.Put("a", 1)
+	_ = m.Put("b", 3)
+	return m
+}
+
+func TestMultiMap_Put(t *testing.T) {
+	m := fetchMultiMap()
+	assert.Equal(t, uint64(4), m.Size())
+	assert.Equal(t, uint64(2), m.KeysCount())
+	assert.Equal(t, []int{1, 2, 1}, m.GetAll("a"))
+	assert.Equal(t, []int{3}, m.GetAll("b"))
+	assert.Nil(t, m.GetAll("c"))
+	assert.ElementsMatch(t, []string{"a", "b"}, m.Keys())
+
+	got := m.GetAll("a")
+	got[0] = 100
+	assert.Equal(t, []int{1, 2, 1}, m.GetAll("a"), "GetAll must return a copy")
+}
+
+func TestMultiMap_RemoveValue(t *testing.T) {
+	type testCase struct {
+		name    string
+		key     string
+		value   int
+		wantErr error
+		want    []int
+		size    uint64
+	}
+	tests := []testCase{
+		{name: "remove first occurrence", key: "a", value: 1, want: []int{2, 1}, size: 3},
+		{name: "remove middle", key: "a", value: 2, want: []int{1, 1}, size: 3},
+		{name: "remove last value drops key", key: "b", value: 3, want: nil, size: 3},
+		{name: "got error when value is absent", key: "a", value: 5, wantErr: ErrValueIsNotFound, want: []int{1, 2, 1}, size: 4},
+		{name: "got error when key is absent", key: "c", value: 1, wantErr: ErrElementIsEmptyByKey, want: nil, size: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := fetchMultiMap()
+			assert.ErrorIs(t, m.RemoveValue(tt.key, tt.value), tt.wantErr)
+			assert.Equal(t, tt.want, m.GetAll(tt.key))
+			assert.Equal(t, tt.size, m.Size())
+			assert.Equal(t, tt.want != nil, m.Contains(tt.key))
+		})
+	}
+}
+
+func TestMultiMap_RemoveAll(t *testing.T) {
+	m := fetchMultiMap()
+	got, err := m.RemoveAll("a")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 1}, got)
+	assert.Equal(t, uint64(1), m.Size())
+	assert.False(t, m.Contains("a"))
+
+	_, err = m.RemoveAll("a")
+	assert.ErrorIs(t, err, ErrElementIsEmptyByKey)
+}
+
+func TestMultiMap_ContainsEntry(t *testing.T) {
+	m := fetchMultiMap()
+	assert.True(t, m.ContainsEntry("a", 2))
+	assert.False(t, m.ContainsEntry("a", 3))
+	assert.False(t, m.ContainsEntry("c", 3))
+	assert.True(t, NewMultiMap[string, int]().IsEmpty())
+}
+
+func TestMultiMap_Range(t *testing.T) {
+	m := fetchMultiMap()
+	var count int
+	assert.NoError(t, m.Range(func(key string, value int) bool {
+		count++
+		return true
+	}))
+	assert.Equal(t, 4, count)
+
+	count = 0
+	assert.NoError(t, m.Range(func(key string, value int) bool {
+		count++
+		return count < 2
+	}))
+	assert.Equal(t, 2, count)
+}
+
+func TestMultiMap_GrowError(t *testing.T) {
+	m := NewMultiMap[string, int]()
+	h := MockHasher[string]{}
+	// every new key hashes twice, the 13th one is stored and then fails to rehash
+	h.On("Hash", mock.AnythingOfType("string")).
+		Return(uint64(0), nil).Times(26)
+	h.On("Hash", mock.AnythingOfType("string")).
+		Return(uint64(0), errors.New("test err"))
+	m.table.hasher = &h
+
+	for i := 0; i < 12; i++ {
+		assert.NoError(t, m.Put(fmt.Sprint(i), i))
+	}
+	assert.Error(t, m.Put("12", 12))
+	assert.Equal(t, uint64(13), m.KeysCount())
+	assert.Equal(t, m.KeysCount(), m.Size(), "size must follow the stored values")
+}
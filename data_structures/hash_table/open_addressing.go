@@ -0,0 +1,280 @@
+package hash_table
+
+import "fmt"
+
+const openAddressingMaxLoadFactor float64 = 0.75
+
+type ProbingStrategy uint8
+
+const (
+	LinearProbing ProbingStrategy = iota
+	RobinHoodProbing
+)
+
+func (p ProbingStrategy) String() string {
+	switch p {
+	case LinearProbing:
+		return "linear"
+	case RobinHoodProbing:
+		return "robin hood"
+	}
+	return fmt.Sprintf("ProbingStrategy(%d)", p)
+}
+
+type slotState uint8
+
+const (
+	slotEmpty slotState = iota
+	slotOccupied
+	slotDeleted
+)
+
+type slot[K comparable, T any] struct {
+	key   K
+	value T
+	state slotState
+	// distance from the home bucket, used by robin hood probing
+	dist uint64
+}
+
+// OpenAddressingTable keeps entries inline in a single slice.
+// Linear probing marks removed slots with tombstones,
+// robin hood probing shifts the following entries back instead
+type OpenAddressingTable[K comparable, T any] struct {
+	size     uint64
+	deleted  uint64
+	capacity uint64
+	strategy ProbingStrategy
+	slots    []slot[K, T]
+	hasher   Hasher[K]
+}
+
+func (t *OpenAddressingTable[K, T]) Capacity() uint64 {
+	return t.capacity
+}
+
+func (t *OpenAddressingTable[K, T]) Size() uint64 {
+	return t.size
+}
+
+func (t *OpenAddressingTable[K, T]) IsEmpty() bool {
+	return t.size < 1
+}
+
+func (t *OpenAddressingTable[K, T]) Strategy() ProbingStrategy {
+	return t.strategy
+}
+
+func (t *OpenAddressingTable[K, T]) home(key K) (uint64, error) {
+	hashVal, err := t.hasher.Hash(key)
+	if err != nil {
+		return 0, err
+	}
+	return (t.capacity - 1) & (hashVal ^ (hashVal >> 16)), nil
+}
+
+// find returns the slot index of key or false when key is absent
+func (t *OpenAddressingTable[K, T]) find(key K) (uint64, bool, error) {
+	i, err := t.home(key)
+	if err != nil {
+		return 0, false, err
+	}
+	mask := t.capacity - 1
+	for dist := uint64(0); dist < t.capacity; dist++ {
+		s := &t.slots[i]
+		if s.state == slotEmpty {
+			return 0, false, nil
+		}
+		if t.strategy == RobinHoodProbing && s.dist < dist {
+			return 0, false, nil
+		}
+		if s.state == slotOccupied && s.key == key {
+			return i, true, nil
+		}
+		i = (i + 1) & mask
+	}
+	return 0, false, nil
+}
+
+func (t *OpenAddressingTable[K, T]) Get(key K) (T, error) {
+	i, ok, err := t.find(key)
+	if err != nil || !ok {
+		return *new(T), newKeyNotFoundError(key, err)
+	}
+	return t.slots[i].value, nil
+}
+
+func (t *OpenAddressingTable[K, T]) Lookup(key K) (T, bool) {
+	i, ok, err := t.find(key)
+	if err != nil || !ok {
+		return *new(T), false
+	}
+	return t.slots[i].value, true
+}
+
+func (t *OpenAddressingTable[K, T]) Contains(key K) (bool, error) {
+	_, ok, err := t.find(key)
+	if err != nil {
+		return false, newKeyNotFoundError(key, err)
+	}
+	return ok, nil
+}
+
+func (t *OpenAddressingTable[K, T]) Put(key K, val T) error {
+	i, ok, err := t.find(key)
+	if err != nil {
+		return err
+	}
+	if ok {
+		t.slots[i].value = val
+		return nil
+	}
+	if float64(t.size+t.deleted+1) > float64(t.capacity)*openAddressingMaxLoadFactor {
+		if err = t.grow(); err != nil {
+			return err
+		}
+	}
+	home, err := t.home(key)
+	if err != nil {
+		return err
+	}
+	if t.strategy == RobinHoodProbing {
+		t.insertRobinHood(slot[K, T]{key: key, value: val, state: slotOccupied}, home)
+	} else {
+		t.insertLinear(slot[K, T]{key: key, value: val, state: slotOccupied}, home)
+	}
+	t.size++
+	return nil
+}
+
+func (t *OpenAddressingTable[K, T]) insertLinear(s slot[K, T], i uint64) {
+	mask := t.capacity - 1
+	for t.slots[i].state == slotOccupied {
+		i = (i + 1) & mask
+	}
+	if t.slots[i].state == slotDeleted {
+		t.deleted--
+	}
+	t.slots[i] = s
+}
+
+func (t *OpenAddressingTable[K, T]) insertRobinHood(s slot[K, T], i uint64) {
+	mask := t.capacity - 1
+	for t.slots[i].state == slotOccupied {
+		// steal the slot from the richer entry
+		if t.slots[i].dist < s.dist {
+			s, t.slots[i] = t.slots[i], s
+		}
+		s.dist++
+		i = (i + 1) & mask
+	}
+	t.slots[i] = s
+}
+
+func (t *OpenAddressingTable[K, T]) Remove(key K) error {
+	i, ok, err := t.find(key)
+	if err != nil || !ok {
+		return newKeyNotFoundError(key, err)
+	}
+	t.size--
+	if t.strategy != RobinHoodProbing {
+		t.slots[i] = slot[K, T]{state: slotDeleted}
+		t.deleted++
+		return nil
+	}
+	mask := t.capacity - 1
+	for next := (i + 1) & mask; t.slots[next].state == slotOccupied && t.slots[next].dist > 0; next = (next + 1) & mask {
+		t.slots[i] = t.slots[next]
+		t.slots[i].dist--
+		i = next
+	}
+	t.slots[i] = slot[K, T]{}
+	return nil
+}
+
+func (t *OpenAddressingTable[K, T]) Foreach(tFunc HashTableFunc[T]) {
+	for i := range t.slots {
+		if t.slots[i].state == slotOccupied {
+			tFunc(t.slots[i].value)
+		}
+	}
+}
+
+func (t *OpenAddressingTable[K, T]) Keys() []K {
+	var keys []K
+	for i := range t.slots {
+		if t.slots[i].state == slotOccupied {
+			keys = append(keys, t.slots[i].key)
+		}
+	}
+	return keys
+}
+
+func (t *OpenAddressingTable[K, T]) Values() []T {
+	var values []T
+	for i := range t.slots {
+		if t.slots[i].state == slotOccupied {
+			values = append(values, t.slots[i].value)
+		}
+	}
+	return values
+}
+
+func (t *OpenAddressingTable[K, T]) Clear() {
+	t.size = 0
+	t.deleted = 0
+	clear(t.slots)
+}
+
+// grow doubles the capacity, or only drops tombstones when they take most of the space
+func (t *OpenAddressingTable[K, T]) grow() error {
+	newCapacity := t.capacity << 1
+	if t.deleted > t.size {
+		newCapacity = t.capacity
+	}
+	return t.rehash(newCapacity)
+}
+
+func (t *OpenAddressingTable[K, T]) rehash(newCapacity uint64) error {
+	old, oldCapacity, oldDeleted := t.slots, t.capacity, t.deleted
+	t.slots = make([]slot[K, T], newCapacity)
+	t.capacity = newCapacity
+	t.deleted = 0
+	for _, s := range old {
+		if s.state != slotOccupied {
+			continue
+		}
+		home, err := t.home(s.key)
+		if err != nil {
+			t.slots, t.capacity, t.deleted = old, oldCapacity, oldDeleted
+			return err
+		}
+		s.dist = 0
+		if t.strategy == RobinHoodProbing {
+			t.insertRobinHood(s, home)
+		} else {
+			t.insertLinear(s, home)
+		}
+	}
+	return nil
+}
+
+func NewOpenAddressingTable[K comparable, T any](strategy ProbingStrategy, opts ...Option[K]) *OpenAddressingTable[K, T] {
+	t, _ := NewOpenAddressingTableWithCapacity[K, T](defaultCapacity, strategy, opts...)
+	return t
+}
+
+// NewOpenAddressingTableWithCapacity rounds capacity up to a power of two
+func NewOpenAddressingTableWithCapacity[K comparable, T any](capacity uint64, strategy ProbingStrategy, opts ...Option[K]) (*OpenAddressingTable[K, T], error) {
+	if err := checkCapacity(capacity); err != nil {
+		return new(OpenAddressingTable[K, T]), err
+	}
+	capacity = roundUpPowerOfTwo(capacity)
+	o := newOptions(opts)
+	return &OpenAddressingTable[K, T]{
+		capacity: capacity,
+		strategy: strategy,
+		slots:    make([]slot[K, T], capacity),
+		hasher:   o.hasher,
+	}, nil
+}
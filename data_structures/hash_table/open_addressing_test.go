@@ -0,0 +1,255 @@
+package hash_table
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"math/rand"
+	"testing"
+)
+
+var probingStrategies = []ProbingStrategy{LinearProbing, RobinHoodProbing}
+
+func TestNewOpenAddressingTableWithCapacity(t *testing.T) {
+	type testCase struct {
+		name     string
+		capacity uint64
+		want     uint64
+		wantErr  assert.ErrorAssertionFunc
+	}
+	tests := []testCase{
+		{name: "got error when empty", capacity: 0, wantErr: assert.Error},
+		{name: "power of two", capacity: 64, want: 64, wantErr: assert.NoError},
+		{name: "rounded up", capacity: 150, want: 256, wantErr: assert.NoError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewOpenAddressingTableWithCapacity[int, int](tt.capacity, LinearProbing)
+			if !tt.wantErr(t, err, fmt.Sprintf("NewOpenAddressingTableWithCapacity(%v)", tt.capacity)) {
+				return
+			}
+			assert.Equal(t, tt.want, got.Capacity())
+		})
+	}
+}
+
+func TestOpenAddressingTable_PutGet(t *testing.T) {
+	for _, strategy := range probingStrategies {
+		t.Run(fmt.Sprint(strategy), func(t *testing.T) {
+			ot := NewOpenAddressingTable[int, *people](strategy)
+			for i := 0; i < 100; i++ {
+				assert.NoError(t, ot.Put(i, &people{name: "test", age: i}))
+			}
+			assert.NoError(t, ot.Put(5, &people{name: "updated", age: 5}))
+			assert.Equal(t, uint64(100), ot.Size())
+			assert.LessOrEqual(t, float64(ot.Size()), float64(ot.Capacity())*openAddressingMaxLoadFactor)
+
+			for i := 0; i < 100; i++ {
+				got, err := ot.Get(i)
+				assert.NoError(t, err)
+				assert.Equal(t, i, got.age)
+			}
+			got, _ := ot.Get(5)
+			assert.Equal(t, "updated", got.name)
+
+			_, err := ot.Get(100)
+			assert.ErrorIs(t, err, ErrElementIsEmptyByKey)
+			ok, err := ot.Contains(100)
+			assert.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestOpenAddressingTable_Remove(t *testing.T) {
+	for _, strategy := range probingStrategies {
+		t.Run(fmt.Sprint(strategy), func(t *testing.T) {
+			ot, _ := NewOpenAddressingTableWithCapacity[int, int](64, strategy)
+			for i := 0; i < 40; i++ {
+				assert.NoError(t, ot.Put(i, i))
+			}
+			for i := 0; i < 40; i += 2 {
+				assert.NoError(t, ot.Remove(i))
+			}
+			assert.ErrorIs(t, ot.Remove(0), ErrElementIsEmptyByKey)
+			assert.Equal(t, uint64(20), ot.Size())
+			for i := 0; i < 40; i++ {
+				ok, err := ot.Contains(i)
+				assert.NoError(t, err)
+				assert.Equalf(t, i%2 == 1, ok, "Contains(%v)", i)
+			}
+			if strategy == LinearProbing {
+				assert.Equal(t, uint64(20), ot.deleted)
+			} else {
+				assert.Zero(t, ot.deleted)
+			}
+		})
+	}
+}
+
+func TestOpenAddressingTable_Tombstones(t *testing.T) {
+	ot, _ := NewOpenAddressingTableWithCapacity[int, int](16, LinearProbing)
+	for round := 0; round < 100; round++ {
+		assert.NoError(t, ot.Put(round, round))
+		assert.NoError(t, ot.Remove(round))
+	}
+	assert.True(t, ot.IsEmpty())
+	assert.Equal(t, uint64(16), ot.Capacity(), "tombstones must be purged instead of growing")
+	assert.Less(t, ot.deleted, ot.Capacity())
+}
+
+func TestOpenAddressingTable_RobinHoodInvariant(t *testing.T) {
+	ot, _ := NewOpenAddressingTableWithCapacity[int, int](256, RobinHoodProbing)
+	for i := 0; i < 180; i++ {
+		assert.NoError(t, ot.Put(i*7, i))
+	}
+	for i := 0; i < 180; i += 3 {
+		assert.NoError(t, ot.Remove(i*7))
+	}
+	for i, s := range ot.slots {
+		if s.state != slotOccupied {
+			continue
+		}
+		home, _ := ot.home(s.key)
+		assert.Equalf(t, (uint64(i)-home)&(ot.capacity-1), s.dist, "slot %v", i)
+	}
+}
+
+func TestOpenAddressingTable_Random(t *testing.T) {
+	for _, strategy := range probingStrategies {
+		t.Run(fmt.Sprint(strategy), func(t *testing.T) {
+			rnd := rand.New(rand.NewSource(1))
+			ot := NewOpenAddressingTable[int, int](strategy, WithHasher[int](IntegerHasher[int]{}))
+			want := make(map[int]int)
+			for i := 0; i < 10000; i++ {
+				key := rnd.Intn(500)
+				if rnd.Intn(3) == 0 {
+					_, ok := want[key]
+					err := ot.Remove(key)
+					assert.Equal(t, ok, err == nil)
+					delete(want, key)
+					continue
+				}
+				want[key] = i
+				assert.NoError(t, ot.Put(key, i))
+			}
+			assert.Equal(t, uint64(len(want)), ot.Size())
+			assert.Len(t, ot.Keys(), len(want))
+			assert.Len(t, ot.Values(), len(want))
+			for k, v := range want {
+				got, err := ot.Get(k)
+				assert.NoError(t, err)
+				assert.Equal(t, v, got)
+			}
+		})
+	}
+}
+
+func TestOpenAddressingTable_HasherError(t *testing.T) {
+	ot := NewOpenAddressingTable[int, int](LinearProbing)
+	h := MockHasher[int]{}
+	h.On("Hash", mock.AnythingOfType("int")).
+		Return(uint64(0), errors.New("test err"))
+	ot.hasher = &h
+
+	_, err := ot.Get(1)
+	assert.Error(t, err)
+	assert.Error(t, ot.Put(1, 1))
+	assert.Error(t, ot.Remove(1))
+	_, err = ot.Contains(1)
+	assert.Error(t, err)
+}
+
+func TestOpenAddressingTable_RehashHasherError(t *testing.T) {
+	ot := NewOpenAddressingTable[int, int](LinearProbing)
+	for i := 0; i < 10; i++ {
+		_ = ot.Put(i, i)
+	}
+	for i := 0; i < 4; i++ {
+		_ = ot.Remove(i)
+	}
+	slots := append([]slot[int, int](nil), ot.slots...)
+	h := MockHasher[int]{}
+	h.On("Hash", mock.AnythingOfType("int")).
+		Return(uint64(0), errors.New("test err"))
+	ot.hasher = &h
+
+	assert.Error(t, ot.rehash(ot.capacity<<1))
+	assert.Equal(t, defaultCapacity, ot.Capacity())
+	assert.Equal(t, uint64(4), ot.deleted, "tombstones must be restored with the slots")
+	assert.Equal(t, slots, ot.slots)
+}
+
+func TestOpenAddressingTable_Clear(t *testing.T) {
+	ot := NewOpenAddressingTable[int, int](LinearProbing)
+	for i := 0; i < 10; i++ {
+		_ = ot.Put(i, i)
+	}
+	_ = ot.Remove(3)
+	ot.Clear()
+	assert.True(t, ot.IsEmpty())
+	assert.Zero(t, ot.deleted)
+	assert.Nil(t, ot.Keys())
+
+	var sum int
+	_ = ot.Put(1, 10)
+	ot.Foreach(func(val int) { sum += val })
+	assert.Equal(t, 10, sum)
+}
+
+func BenchmarkTables(b *testing.B) {
+	const n = 1 << 12
+	type table interface {
+		Put(key int, val int) error
+		Get(key int) (int, error)
+	}
+	tables := map[string]func() table{
+		"chaining": func() table { return NewHashTable[int, int](WithHasher[int](IntegerHasher[int]{})) },
+		"linear": func() table {
+			return NewOpenAddressingTable[int, int](LinearProbing, WithHasher[int](IntegerHasher[int]{}))
+		},
+		"robinhood": func() table {
+			return NewOpenAddressingTable[int, int](RobinHoodProbing, WithHasher[int](IntegerHasher[int]{}))
+		},
+	}
+	for name, newTable := range tables {
+		b.Run(name+"/put", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				t := newTable()
+				for k := 0; k < n; k++ {
+					_ = t.Put(k, k)
+				}
+			}
+		})
+		b.Run(name+"/get", func(b *testing.B) {
+			t := newTable()
+			for k := 0; k < n; k++ {
+				_ = t.Put(k, k)
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = t.Get(i & (n - 1))
+			}
+		})
+	}
+}
+
+func TestOpenAddressingTable_Lookup(t *testing.T) {
+	for _, strategy := range probingStrategies {
+		t.Run(fmt.Sprint(strategy), func(t *testing.T) {
+			ot := NewOpenAddressingTable[int, int](strategy)
+			_ = ot.Put(1, 10)
+			got, ok := ot.Lookup(1)
+			assert.True(t, ok)
+			assert.Equal(t, 10, got)
+			_, ok = ot.Lookup(2)
+			assert.False(t, ok)
+
+			_, err := ot.Get(2)
+			var notFound *KeyNotFoundError[int]
+			assert.ErrorAs(t, err, &notFound)
+			assert.Equal(t, 2, notFound.Key)
+		})
+	}
+}
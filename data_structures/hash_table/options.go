@@ -0,0 +1,28 @@
+package hash_table
+
+type options[K comparable] struct {
+	hasher Hasher[K]
+}
+
+type Option[K comparable] func(o *options[K])
+
+func WithHasher[K comparable](hasher Hasher[K]) Option[K] {
+	return func(o *options[K]) {
+		if hasher != nil {
+			o.hasher = hasher
+		}
+	}
+}
+
+func newOptions[K comparable](opts []Option[K]) *options[K] {
+	o := &options[K]{hasher: FNVHasher[K]{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// HasherFrom returns the hasher chosen by opts, so other packages accept the same options
+func HasherFrom[K comparable](opts ...Option[K]) Hasher[K] {
+	return newOptions(opts).hasher
+}
@@ -0,0 +1,357 @@
+package hash_table
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+const (
+	hamtBits uint   = 5
+	hamtMask uint64 = 1<<hamtBits - 1
+	// hamtMaxShift is reached when all hash bits are used, such nodes keep full collisions
+	hamtMaxShift uint = 64
+)
+
+// edit marks nodes owned by a builder, they may be changed in place.
+// It isn't empty because pointers to zero sized values may be equal
+type edit struct {
+	_ byte
+}
+
+type hamtEntry[K comparable, T any] struct {
+	hash  uint64
+	key   K
+	value T
+	child *hamtNode[K, T]
+}
+
+type hamtNode[K comparable, T any] struct {
+	bitmap  uint32
+	entries []hamtEntry[K, T]
+	edit    *edit
+}
+
+func (n *hamtNode[K, T]) clone(e *edit) *hamtNode[K, T] {
+	if e != nil && n.edit == e {
+		return n
+	}
+	c := &hamtNode[K, T]{bitmap: n.bitmap, entries: make([]hamtEntry[K, T], len(n.entries), len(n.entries)+1), edit: e}
+	copy(c.entries, n.entries)
+	return c
+}
+
+func (n *hamtNode[K, T]) position(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & hamtMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *hamtNode[K, T]) find(hash uint64, key K) *hamtEntry[K, T] {
+	for shift := uint(0); ; shift += hamtBits {
+		if shift >= hamtMaxShift {
+			for i := range n.entries {
+				if n.entries[i].key == key {
+					return &n.entries[i]
+				}
+			}
+			return nil
+		}
+		bit, i := n.position(hash, shift)
+		if n.bitmap&bit == 0 {
+			return nil
+		}
+		cur := &n.entries[i]
+		if cur.child == nil {
+			if cur.key == key {
+				return cur
+			}
+			return nil
+		}
+		n = cur.child
+	}
+}
+
+// put returns the changed node and whether the key is new
+func (n *hamtNode[K, T]) put(shift uint, leaf hamtEntry[K, T], e *edit) (*hamtNode[K, T], bool) {
+	if shift >= hamtMaxShift {
+		c := n.clone(e)
+		for i := range c.entries {
+			if c.entries[i].key == leaf.key {
+				c.entries[i] = leaf
+				return c, false
+			}
+		}
+		c.entries = append(c.entries, leaf)
+		return c, true
+	}
+	bit, i := n.position(leaf.hash, shift)
+	if n.bitmap&bit == 0 {
+		c := n.clone(e)
+		c.entries = slices.Insert(c.entries, i, leaf)
+		c.bitmap |= bit
+		return c, true
+	}
+	cur := n.entries[i]
+	c := n.clone(e)
+	switch {
+	case cur.child != nil:
+		child, added := cur.child.put(shift+hamtBits, leaf, e)
+		c.entries[i] = hamtEntry[K, T]{child: child}
+		return c, added
+	case cur.key == leaf.key:
+		c.entries[i] = leaf
+		return c, false
+	default:
+		c.entries[i] = hamtEntry[K, T]{child: newHamtBranch(shift+hamtBits, cur, leaf, e)}
+		return c, true
+	}
+}
+
+func newHamtBranch[K comparable, T any](shift uint, a, b hamtEntry[K, T], e *edit) *hamtNode[K, T] {
+	n := &hamtNode[K, T]{edit: e}
+	if shift >= hamtMaxShift {
+		n.entries = []hamtEntry[K, T]{a, b}
+		return n
+	}
+	ai, bi := (a.hash>>shift)&hamtMask, (b.hash>>shift)&hamtMask
+	if ai == bi {
+		n.bitmap = 1 << ai
+		n.entries = []hamtEntry[K, T]{{child: newHamtBranch(shift+hamtBits, a, b, e)}}
+		return n
+	}
+	n.bitmap = 1<<ai | 1<<bi
+	if ai < bi {
+		n.entries = []hamtEntry[K, T]{a, b}
+	} else {
+		n.entries = []hamtEntry[K, T]{b, a}
+	}
+	return n
+}
+
+// remove returns the changed node and whether the key was found
+func (n *hamtNode[K, T]) remove(shift uint, hash uint64, key K, e *edit) (*hamtNode[K, T], bool) {
+	if shift >= hamtMaxShift {
+		for i := range n.entries {
+			if n.entries[i].key == key {
+				c := n.clone(e)
+				c.entries = slices.Delete(c.entries, i, i+1)
+				return c, true
+			}
+		}
+		return n, false
+	}
+	bit, i := n.position(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	cur := n.entries[i]
+	if cur.child == nil {
+		if cur.key != key {
+			return n, false
+		}
+		c := n.clone(e)
+		c.entries = slices.Delete(c.entries, i, i+1)
+		c.bitmap &^= bit
+		return c, true
+	}
+	child, removed := cur.child.remove(shift+hamtBits, hash, key, e)
+	if !removed {
+		return n, false
+	}
+	c := n.clone(e)
+	switch {
+	case len(child.entries) == 0:
+		c.entries = slices.Delete(c.entries, i, i+1)
+		c.bitmap &^= bit
+	case len(child.entries) == 1 && child.entries[0].child == nil:
+		// a single leaf doesn't need its own branch
+		c.entries[i] = child.entries[0]
+	default:
+		c.entries[i] = hamtEntry[K, T]{child: child}
+	}
+	return c, true
+}
+
+func (n *hamtNode[K, T]) walk(yield func(K, T) bool) bool {
+	for i := range n.entries {
+		cur := &n.entries[i]
+		if cur.child != nil {
+			if !cur.child.walk(yield) {
+				return false
+			}
+			continue
+		}
+		if !yield(cur.key, cur.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// PersistentHashMap is an immutable hash array mapped trie,
+// every change returns a new version sharing untouched nodes with the old one,
+// so versions can be read from any goroutine without copying
+type PersistentHashMap[K comparable, T any] struct {
+	size   uint64
+	root   *hamtNode[K, T]
+	hasher Hasher[K]
+}
+
+func (m *PersistentHashMap[K, T]) Size() uint64 {
+	return m.size
+}
+
+func (m *PersistentHashMap[K, T]) IsEmpty() bool {
+	return m.size < 1
+}
+
+func (m *PersistentHashMap[K, T]) Get(key K) (T, error) {
+	hash, err := m.hasher.Hash(key)
+	if err != nil {
+		return *new(T), newKeyNotFoundError(key, err)
+	}
+	if e := m.root.find(hash, key); e != nil {
+		return e.value, nil
+	}
+	return *new(T), newKeyNotFoundError(key, nil)
+}
+
+func (m *PersistentHashMap[K, T]) Lookup(key K) (T, bool) {
+	hash, err := m.hasher.Hash(key)
+	if err != nil {
+		return *new(T), false
+	}
+	if e := m.root.find(hash, key); e != nil {
+		return e.value, true
+	}
+	return *new(T), false
+}
+
+func (m *PersistentHashMap[K, T]) Contains(key K) (bool, error) {
+	hash, err := m.hasher.Hash(key)
+	if err != nil {
+		return false, newKeyNotFoundError(key, err)
+	}
+	return m.root.find(hash, key) != nil, nil
+}
+
+func (m *PersistentHashMap[K, T]) Put(key K, val T) (*PersistentHashMap[K, T], error) {
+	hash, err := m.hasher.Hash(key)
+	if err != nil {
+		return m, err
+	}
+	root, added := m.root.put(0, hamtEntry[K, T]{hash: hash, key: key, value: val}, nil)
+	pm := &PersistentHashMap[K, T]{size: m.size, root: root, hasher: m.hasher}
+	if added {
+		pm.size++
+	}
+	return pm, nil
+}
+
+func (m *PersistentHashMap[K, T]) Remove(key K) (*PersistentHashMap[K, T], error) {
+	hash, err := m.hasher.Hash(key)
+	if err != nil {
+		return m, newKeyNotFoundError(key, err)
+	}
+	root, removed := m.root.remove(0, hash, key, nil)
+	if !removed {
+		return m, newKeyNotFoundError(key, nil)
+	}
+	return &PersistentHashMap[K, T]{size: m.size - 1, root: root, hasher: m.hasher}, nil
+}
+
+func (m *PersistentHashMap[K, T]) All() iter.Seq2[K, T] {
+	return func(yield func(K, T) bool) {
+		m.root.walk(yield)
+	}
+}
+
+func (m *PersistentHashMap[K, T]) Foreach(tFunc HashTableFunc[T]) {
+	m.root.walk(func(key K, val T) bool {
+		tFunc(val)
+		return true
+	})
+}
+
+func (m *PersistentHashMap[K, T]) Keys() []K {
+	var keys []K
+	for key := range m.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (m *PersistentHashMap[K, T]) Values() []T {
+	var values []T
+	for _, val := range m.All() {
+		values = append(values, val)
+	}
+	return values
+}
+
+// Builder starts a transient copy of m for bulk changes
+func (m *PersistentHashMap[K, T]) Builder() *PersistentHashMapBuilder[K, T] {
+	return &PersistentHashMapBuilder[K, T]{size: m.size, root: m.root, hasher: m.hasher, edit: &edit{}}
+}
+
+// PersistentHashMapBuilder changes the nodes it has already copied in place,
+// so bulk loads don't allocate a new path for every key. It isn't safe for concurrent use
+type PersistentHashMapBuilder[K comparable, T any] struct {
+	size   uint64
+	root   *hamtNode[K, T]
+	hasher Hasher[K]
+	edit   *edit
+}
+
+func (b *PersistentHashMapBuilder[K, T]) Size() uint64 {
+	return b.size
+}
+
+func (b *PersistentHashMapBuilder[K, T]) Lookup(key K) (T, bool) {
+	hash, err := b.hasher.Hash(key)
+	if err != nil {
+		return *new(T), false
+	}
+	if e := b.root.find(hash, key); e != nil {
+		return e.value, true
+	}
+	return *new(T), false
+}
+
+func (b *PersistentHashMapBuilder[K, T]) Put(key K, val T) error {
+	hash, err := b.hasher.Hash(key)
+	if err != nil {
+		return err
+	}
+	root, added := b.root.put(0, hamtEntry[K, T]{hash: hash, key: key, value: val}, b.edit)
+	b.root = root
+	if added {
+		b.size++
+	}
+	return nil
+}
+
+func (b *PersistentHashMapBuilder[K, T]) Remove(key K) error {
+	hash, err := b.hasher.Hash(key)
+	if err != nil {
+		return newKeyNotFoundError(key, err)
+	}
+	root, removed := b.root.remove(0, hash, key, b.edit)
+	if !removed {
+		return newKeyNotFoundError(key, nil)
+	}
+	b.root = root
+	b.size--
+	return nil
+}
+
+// Build returns the persistent version, later changes of the builder don't affect it
+func (b *PersistentHashMapBuilder[K, T]) Build() *PersistentHashMap[K, T] {
+	b.edit = &edit{}
+	return &PersistentHashMap[K, T]{size: b.size, root: b.root, hasher: b.hasher}
+}
+
+func NewPersistentHashMap[K comparable, T any](opts ...Option[K]) *PersistentHashMap[K, T] {
+	o := newOptions(opts)
+	return &PersistentHashMap[K, T]{root: &hamtNode[K, T]{}, hasher: o.hasher}
+}
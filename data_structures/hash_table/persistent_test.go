@@ -0,0 +1,250 @@
+package hash_table
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"math/rand"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// collidingHasher gives every key the same hash so only collision nodes are used
+func collidingHasher() Option[int] {
+	return WithHasher[int](BytesHasher[int](func(int) []byte { return []byte("same") }))
+}
+
+func assertPersistentMap(t *testing.T, want map[int]int, m *PersistentHashMap[int, int]) {
+	t.Helper()
+	assert.Equal(t, uint64(len(want)), m.Size())
+	got := make(map[int]int, len(want))
+	for k, v := range m.All() {
+		got[k] = v
+	}
+	assert.Equal(t, want, got)
+	for k, v := range want {
+		val, ok := m.Lookup(k)
+		assert.True(t, ok)
+		assert.Equal(t, v, val)
+	}
+}
+
+func TestPersistentHashMap_Versions(t *testing.T) {
+	v0 := NewPersistentHashMap[string, int]()
+	v1, err := v0.Put("a", 1)
+	assert.NoError(t, err)
+	v2, _ := v1.Put("b", 2)
+	v3, _ := v2.Put("a", 10)
+	v4, err := v3.Remove("b")
+	assert.NoError(t, err)
+
+	assert.True(t, v0.IsEmpty())
+	assert.Equal(t, uint64(1), v1.Size())
+	assert.Equal(t, uint64(2), v2.Size())
+	assert.Equal(t, uint64(2), v3.Size())
+	assert.Equal(t, uint64(1), v4.Size())
+
+	got, _ := v1.Get("a")
+	assert.Equal(t, 1, got)
+	got, _ = v3.Get("a")
+	assert.Equal(t, 10, got)
+	ok, _ := v2.Contains("b")
+	assert.True(t, ok)
+	ok, _ = v4.Contains("b")
+	assert.False(t, ok)
+
+	_, err = v0.Get("a")
+	assert.ErrorIs(t, err, ErrElementIsEmptyByKey)
+	same, err := v4.Remove("b")
+	var notFound *KeyNotFoundError[string]
+	assert.ErrorAs(t, err, &notFound)
+	assert.Equal(t, "b", notFound.Key)
+	assert.Same(t, v4, same)
+}
+
+func TestPersistentHashMap_StructuralSharing(t *testing.T) {
+	m := NewPersistentHashMap[int, int](WithHasher[int](IntegerHasher[int]{}))
+	for i := 0; i < 1000; i++ {
+		m, _ = m.Put(i, i)
+	}
+	next, _ := m.Put(1000, 1000)
+	shared := 0
+	for i := range m.root.entries {
+		for j := range next.root.entries {
+			if c := m.root.entries[i].child; c != nil && c == next.root.entries[j].child {
+				shared++
+			}
+		}
+	}
+	assert.NotSame(t, m.root, next.root)
+	assert.Equal(t, len(m.root.entries)-1, shared, "only the changed path must be copied")
+}
+
+func TestPersistentHashMap_Random(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option[int]
+	}{
+		{name: "fnv"},
+		{name: "integer", opts: []Option[int]{WithHasher[int](IntegerHasher[int]{})}},
+		{name: "collisions", opts: []Option[int]{collidingHasher()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rnd := rand.New(rand.NewSource(1))
+			m := NewPersistentHashMap[int, int](tt.opts...)
+			want := make(map[int]int)
+			type version struct {
+				m    *PersistentHashMap[int, int]
+				want map[int]int
+			}
+			var versions []version
+			for i := 0; i < 3000; i++ {
+				key := rnd.Intn(300)
+				if rnd.Intn(3) == 0 {
+					_, ok := want[key]
+					next, err := m.Remove(key)
+					assert.Equal(t, ok, err == nil)
+					m = next
+					delete(want, key)
+				} else {
+					m, _ = m.Put(key, i)
+					want[key] = i
+				}
+				if i%500 == 0 {
+					snapshot := make(map[int]int, len(want))
+					for k, v := range want {
+						snapshot[k] = v
+					}
+					versions = append(versions, version{m: m, want: snapshot})
+				}
+			}
+			assertPersistentMap(t, want, m)
+			for _, v := range versions {
+				assertPersistentMap(t, v.want, v.m)
+			}
+		})
+	}
+}
+
+func TestPersistentHashMap_RemoveCollapses(t *testing.T) {
+	m := NewPersistentHashMap[int, int](collidingHasher())
+	m, _ = m.Put(1, 1)
+	m, _ = m.Put(2, 2)
+	m, _ = m.Remove(2)
+	assert.Len(t, m.root.entries, 1)
+	assert.Nil(t, m.root.entries[0].child, "a single leaf must be pulled up")
+	m, _ = m.Remove(1)
+	assert.Empty(t, m.root.entries)
+	assert.True(t, m.IsEmpty())
+}
+
+func TestPersistentHashMap_Builder(t *testing.T) {
+	base := NewPersistentHashMap[int, int]()
+	base, _ = base.Put(-1, -1)
+
+	b := base.Builder()
+	for i := 0; i < 1000; i++ {
+		assert.NoError(t, b.Put(i, i))
+	}
+	assert.NoError(t, b.Remove(-1))
+	assert.ErrorIs(t, b.Remove(-1), ErrElementIsEmptyByKey)
+	assert.Equal(t, uint64(1000), b.Size())
+	got, ok := b.Lookup(10)
+	assert.True(t, ok)
+	assert.Equal(t, 10, got)
+
+	built := b.Build()
+	assert.NoError(t, b.Put(0, 100))
+	assert.NoError(t, b.Remove(1))
+	after := b.Build()
+
+	assert.Equal(t, uint64(1), base.Size(), "builder must not change the source map")
+	ok, _ = base.Contains(0)
+	assert.False(t, ok)
+
+	assert.Equal(t, uint64(1000), built.Size())
+	got, _ = built.Get(0)
+	assert.Equal(t, 0, got, "changes after Build must not leak into the built map")
+	ok, _ = built.Contains(1)
+	assert.True(t, ok)
+
+	assert.Equal(t, uint64(999), after.Size())
+	got, _ = after.Get(0)
+	assert.Equal(t, 100, got)
+}
+
+func TestPersistentHashMap_ConcurrentReaders(t *testing.T) {
+	m := NewPersistentHashMap[int, int]()
+	for i := 0; i < iterations; i++ {
+		m, _ = m.Put(i, i)
+	}
+	snapshot := m
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				got, ok := snapshot.Lookup(i)
+				assert.True(t, ok)
+				assert.Equal(t, i, got)
+			}
+		}()
+	}
+	for i := 0; i < iterations; i++ {
+		m, _ = m.Put(i, -i)
+	}
+	wg.Wait()
+	assert.Equal(t, snapshot.Size(), m.Size())
+}
+
+func TestPersistentHashMap_KeysValues(t *testing.T) {
+	m := NewPersistentHashMap[int, int]()
+	assert.Nil(t, m.Keys())
+	for i := 0; i < 5; i++ {
+		m, _ = m.Put(i, i*10)
+	}
+	keys := m.Keys()
+	sort.Ints(keys)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, keys)
+	values := m.Values()
+	sort.Ints(values)
+	assert.Equal(t, []int{0, 10, 20, 30, 40}, values)
+
+	var sum int
+	m.Foreach(func(val int) { sum += val })
+	assert.Equal(t, 100, sum)
+
+	var n int
+	for range m.All() {
+		n++
+		break
+	}
+	assert.Equal(t, 1, n)
+}
+
+func TestPersistentHashMap_HasherError(t *testing.T) {
+	m := NewPersistentHashMap[int, int]()
+	h := MockHasher[int]{}
+	h.On("Hash", mock.AnythingOfType("int")).
+		Return(uint64(0), errors.New("test err"))
+	m.hasher = &h
+
+	_, err := m.Get(1)
+	assert.Error(t, err)
+	_, err = m.Contains(1)
+	assert.Error(t, err)
+	same, err := m.Put(1, 1)
+	assert.Error(t, err)
+	assert.Same(t, m, same)
+	_, err = m.Remove(1)
+	assert.Error(t, err)
+	_, ok := m.Lookup(1)
+	assert.False(t, ok)
+
+	b := m.Builder()
+	assert.Error(t, b.Put(1, 1))
+	assert.Error(t, b.Remove(1))
+}
@@ -0,0 +1,41 @@
+package hash_table
+
+type Stats struct {
+	Buckets      uint64
+	Entries      uint64
+	EmptyBuckets uint64
+	EmptyRatio   float64
+	LongestChain uint64
+	// AverageProbe is the mean count of nodes visited by a successful Get
+	AverageProbe float64
+	// Histogram[n] is the count of buckets holding n entries
+	Histogram []uint64
+}
+
+func (t *HashTable[K, T]) Stats() Stats {
+	st := Stats{Buckets: t.capacity}
+	var probes uint64
+	for _, el := range t.table {
+		var chain uint64
+		for v := el; v != nil; v = v.next {
+			chain++
+			probes += chain
+		}
+		for uint64(len(st.Histogram)) <= chain {
+			st.Histogram = append(st.Histogram, 0)
+		}
+		st.Histogram[chain]++
+		st.Entries += chain
+		st.LongestChain = max(st.LongestChain, chain)
+	}
+	if len(st.Histogram) > 0 {
+		st.EmptyBuckets = st.Histogram[0]
+	}
+	if st.Buckets > 0 {
+		st.EmptyRatio = float64(st.EmptyBuckets) / float64(st.Buckets)
+	}
+	if st.Entries > 0 {
+		st.AverageProbe = float64(probes) / float64(st.Entries)
+	}
+	return st
+}
@@ -0,0 +1,98 @@
+package hash_table
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHashTable_Stats(t *testing.T) {
+	type testCase struct {
+		name string
+		ht   *HashTable[int, int]
+		want Stats
+	}
+	tests := []testCase{
+		{
+			name: "when empty",
+			ht: func() *HashTable[int, int] {
+				mt, _ := NewHashTableWithCapacity[int, int](4)
+				return mt
+			}(),
+			want: Stats{Buckets: 4, EmptyBuckets: 4, EmptyRatio: 1, Histogram: []uint64{4}},
+		},
+		{
+			name: "with chains",
+			ht: func() *HashTable[int, int] {
+				mt, _ := NewHashTableWithCapacity[int, int](4)
+				mt.table[0] = &node[int, int]{key: 1, next: &node[int, int]{key: 2, next: &node[int, int]{key: 3}}}
+				mt.table[2] = &node[int, int]{key: 4}
+				mt.size = 4
+				return mt
+			}(),
+			want: Stats{
+				Buckets:      4,
+				Entries:      4,
+				EmptyBuckets: 2,
+				EmptyRatio:   0.5,
+				LongestChain: 3,
+				AverageProbe: 7.0 / 4.0,
+				Histogram:    []uint64{2, 1, 0, 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.ht.Stats())
+		})
+	}
+}
+
+func TestHashTable_StatsDistribution(t *testing.T) {
+	ht := NewHashTable[int, int]()
+	for i := 0; i < 1000; i++ {
+		_ = ht.Put(i, i)
+	}
+	st := ht.Stats()
+	assert.Equal(t, uint64(1000), st.Entries)
+	assert.Equal(t, ht.Capacity(), st.Buckets)
+	assert.Less(t, st.LongestChain, uint64(8))
+	assert.Less(t, st.EmptyRatio, 0.7)
+}
+
+func TestNewHashTableWithCapacity_PowerOfTwo(t *testing.T) {
+	type testCase struct {
+		capacity uint64
+		want     uint64
+	}
+	tests := []testCase{{1, 1}, {2, 2}, {3, 4}, {150, 256}, {1024, 1024}, {1025, 2048}}
+	for _, tt := range tests {
+		ht, err := NewHashTableWithCapacity[int, int](tt.capacity)
+		assert.NoError(t, err)
+		assert.Equalf(t, tt.want, ht.Capacity(), "NewHashTableWithCapacity(%v)", tt.capacity)
+		assert.Len(t, ht.table, int(tt.want))
+
+		assert.NoError(t, ht.Rehash(tt.capacity+1))
+		assert.Equalf(t, roundUpPowerOfTwo(tt.capacity+1), ht.Capacity(), "Rehash(%v)", tt.capacity+1)
+	}
+}
+
+func TestNewHashTableWithCapacity_TooLarge(t *testing.T) {
+	type testCase struct {
+		capacity uint64
+		wantErr  error
+	}
+	tests := []testCase{{0, ErrCapacityIsEmpty}, {1<<63 + 1, ErrCapacityIsTooLarge}, {^uint64(0), ErrCapacityIsTooLarge}}
+	for _, tt := range tests {
+		_, err := NewHashTableWithCapacity[int, int](tt.capacity)
+		assert.ErrorIsf(t, err, tt.wantErr, "NewHashTableWithCapacity(%v)", tt.capacity)
+		_, err = NewOpenAddressingTableWithCapacity[int, int](tt.capacity, LinearProbing)
+		assert.ErrorIsf(t, err, tt.wantErr, "NewOpenAddressingTableWithCapacity(%v)", tt.capacity)
+		_, err = NewCuckooHashTableWithCapacity[int, int](tt.capacity, 2)
+		assert.ErrorIsf(t, err, tt.wantErr, "NewCuckooHashTableWithCapacity(%v)", tt.capacity)
+
+		ht := NewHashTable[int, int]()
+		assert.ErrorIsf(t, ht.Rehash(tt.capacity), tt.wantErr, "Rehash(%v)", tt.capacity)
+		assert.Equal(t, defaultCapacity, ht.Capacity())
+	}
+	assert.Equal(t, maxCapacity, roundUpPowerOfTwo(1<<62+1))
+}
@@ -6,12 +6,25 @@ type DoubleNode[T any] struct {
 	next     *DoubleNode[T]
 	previous *DoubleNode[T]
 	value    T
+	list     *DoubleLinkedList[T]
 }
 
 func NewDoubleNode[T any](value T) *DoubleNode[T] {
 	return &DoubleNode[T]{value: value}
 }
 
+func (n *DoubleNode[T]) Value() T {
+	return n.value
+}
+
+func (n *DoubleNode[T]) Next() *DoubleNode[T] {
+	return n.next
+}
+
+func (n *DoubleNode[T]) Previous() *DoubleNode[T] {
+	return n.previous
+}
+
 type DoubleLinkedList[T any] struct {
 	size int
 	tail *DoubleNode[T]
@@ -31,8 +44,17 @@ var (
 	ErrHeadIsNotHead   = errors.New("head previous isn't nil")
 )
 
+func (dl *DoubleLinkedList[T]) Head() *DoubleNode[T] {
+	return dl.head
+}
+
+func (dl *DoubleLinkedList[T]) Tail() *DoubleNode[T] {
+	return dl.tail
+}
+
 func (dl *DoubleLinkedList[T]) PushHead(value T) error {
 	node := NewDoubleNode(value)
+	node.list = dl
 	if dl.size < 1 {
 		dl.head = node
 		dl.tail = node
@@ -57,6 +79,7 @@ var ErrTailIsUndefined = errors.New("tail node is nil")
 
 func (dl *DoubleLinkedList[T]) PushTail(value T) error {
 	node := NewDoubleNode(value)
+	node.list = dl
 	if dl.size < 1 {
 		dl.tail = node
 		dl.head = node
@@ -93,6 +116,7 @@ func (dl *DoubleLinkedList[T]) Insert(index int, value T) error {
 		node = node.next
 	}
 	inserted := NewDoubleNode(value)
+	inserted.list = dl
 	inserted.previous = node.previous
 	inserted.next = node
 	node.previous.next = inserted
@@ -129,6 +153,7 @@ func (dl *DoubleLinkedList[T]) Remove(index int) (T, error) {
 		node := dl.head
 		dl.head = node.next
 		dl.head.previous = nil
+		node.list = nil
 		node = nil
 		dl.size--
 		return value, nil
@@ -138,6 +163,7 @@ func (dl *DoubleLinkedList[T]) Remove(index int) (T, error) {
 		node := dl.tail
 		dl.tail = node.previous
 		dl.tail.next = nil
+		node.list = nil
 		node = nil
 		dl.size--
 		return value, nil
@@ -150,6 +176,7 @@ func (dl *DoubleLinkedList[T]) Remove(index int) (T, error) {
 	deletedNode.previous.next = deletedNode.next
 	deletedNode.next.previous = deletedNode.previous
 	value = deletedNode.value
+	deletedNode.list = nil
 	deletedNode = nil
 	dl.size--
 	return value, nil
@@ -167,6 +194,83 @@ func (dl *DoubleLinkedList[T]) ReverseForEach(f func(T)) {
 	}
 }
 
+var (
+	ErrNodeIsLinked    = errors.New("node already belongs to a list")
+	ErrNodeIsNotInList = errors.New("node doesn't belong to the list")
+)
+
+// LinkHead inserts an unlinked node before head in O(1)
+func (dl *DoubleLinkedList[T]) LinkHead(node *DoubleNode[T]) error {
+	if node.list != nil {
+		return ErrNodeIsLinked
+	}
+	node.list = dl
+	node.previous = nil
+	node.next = dl.head
+	if dl.head != nil {
+		dl.head.previous = node
+	} else {
+		dl.tail = node
+	}
+	dl.head = node
+	dl.size++
+	return nil
+}
+
+// LinkTail inserts an unlinked node after tail in O(1)
+func (dl *DoubleLinkedList[T]) LinkTail(node *DoubleNode[T]) error {
+	if node.list != nil {
+		return ErrNodeIsLinked
+	}
+	node.list = dl
+	node.next = nil
+	node.previous = dl.tail
+	if dl.tail != nil {
+		dl.tail.next = node
+	} else {
+		dl.head = node
+	}
+	dl.tail = node
+	dl.size++
+	return nil
+}
+
+// Unlink removes the node in O(1), the node may be linked again afterwards
+func (dl *DoubleLinkedList[T]) Unlink(node *DoubleNode[T]) error {
+	if node.list != dl {
+		return ErrNodeIsNotInList
+	}
+	if node.previous != nil {
+		node.previous.next = node.next
+	} else {
+		dl.head = node.next
+	}
+	if node.next != nil {
+		node.next.previous = node.previous
+	} else {
+		dl.tail = node.previous
+	}
+	node.next = nil
+	node.previous = nil
+	node.list = nil
+	dl.size--
+	return nil
+}
+
+func (dl *DoubleLinkedList[T]) MoveToHead(node *DoubleNode[T]) error {
+	if err := dl.Unlink(node); err != nil {
+		return err
+	}
+	return dl.LinkHead(node)
+}
+
+func (dl *DoubleLinkedList[T]) MoveToTail(node *DoubleNode[T]) error {
+	if err := dl.Unlink(node); err != nil {
+		return err
+	}
+	return dl.LinkTail(node)
+}
+
 func (dl *DoubleLinkedList[T]) checkRange(index int) error {
 	if index < 0 || index > dl.size-1 {
 		return ErrIndexOutOfRange
@@ -298,3 +298,145 @@ func TestDoubleLinkedList_Remove(t *testing.T) {
 		})
 	}
 }
+
+func listValues[T any](dl *DoubleLinkedList[T]) []T {
+	var values []T
+	dl.ForEach(func(value T) {
+		values = append(values, value)
+	})
+	return values
+}
+
+func TestDoubleLinkedList_Link(t *testing.T) {
+	type testCase struct {
+		name    string
+		dl      *DoubleLinkedList[int]
+		link    func(dl *DoubleLinkedList[int], node *DoubleNode[int]) error
+		node    *DoubleNode[int]
+		want    []int
+		wantErr assert.ErrorAssertionFunc
+	}
+	tests := []testCase{
+		{
+			name:    "link head when empty",
+			dl:      NewDoubleLinkedList[int](),
+			link:    (*DoubleLinkedList[int]).LinkHead,
+			node:    NewDoubleNode(10),
+			want:    []int{10},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "link head",
+			dl: func() *DoubleLinkedList[int] {
+				dl := NewDoubleLinkedList[int]()
+				_ = dl.PushTail(1)
+				_ = dl.PushTail(2)
+				return dl
+			}(),
+			link:    (*DoubleLinkedList[int]).LinkHead,
+			node:    NewDoubleNode(10),
+			want:    []int{10, 1, 2},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "link tail when empty",
+			dl:      NewDoubleLinkedList[int](),
+			link:    (*DoubleLinkedList[int]).LinkTail,
+			node:    NewDoubleNode(10),
+			want:    []int{10},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "link tail",
+			dl: func() *DoubleLinkedList[int] {
+				dl := NewDoubleLinkedList[int]()
+				_ = dl.PushTail(1)
+				_ = dl.PushTail(2)
+				return dl
+			}(),
+			link:    (*DoubleLinkedList[int]).LinkTail,
+			node:    NewDoubleNode(10),
+			want:    []int{1, 2, 10},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "got error when node is linked",
+			dl:   NewDoubleLinkedList[int](),
+			link: (*DoubleLinkedList[int]).LinkTail,
+			node: func() *DoubleNode[int] {
+				other := NewDoubleLinkedList[int]()
+				_ = other.PushTail(10)
+				return other.Head()
+			}(),
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.wantErr(t, tt.link(tt.dl, tt.node)) {
+				return
+			}
+			assert.Equal(t, tt.want, listValues(tt.dl))
+			assert.Equal(t, len(tt.want), tt.dl.Length())
+		})
+	}
+}
+
+func TestDoubleLinkedList_Unlink(t *testing.T) {
+	type testCase struct {
+		name    string
+		index   int
+		want    []int
+		wantErr assert.ErrorAssertionFunc
+	}
+	tests := []testCase{
+		{name: "unlink head", index: 0, want: []int{2, 3}, wantErr: assert.NoError},
+		{name: "unlink middle", index: 1, want: []int{1, 3}, wantErr: assert.NoError},
+		{name: "unlink tail", index: 2, want: []int{1, 2}, wantErr: assert.NoError},
+		{name: "got error for foreign node", index: -1, want: []int{1, 2, 3}, wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dl := NewDoubleLinkedList[int]()
+			nodes := make([]*DoubleNode[int], 3)
+			for i := range nodes {
+				nodes[i] = NewDoubleNode(i + 1)
+				_ = dl.LinkTail(nodes[i])
+			}
+			node := NewDoubleNode(0)
+			if tt.index >= 0 {
+				node = nodes[tt.index]
+			}
+			tt.wantErr(t, dl.Unlink(node), fmt.Sprintf("Unlink(%v)", tt.index))
+			assert.Equal(t, tt.want, listValues(dl))
+			assert.Equal(t, len(tt.want), dl.Length())
+		})
+	}
+
+	dl := NewDoubleLinkedList[int]()
+	node := NewDoubleNode(1)
+	_ = dl.LinkHead(node)
+	assert.NoError(t, dl.Unlink(node))
+	assert.Nil(t, dl.Head())
+	assert.Nil(t, dl.Tail())
+	assert.NoError(t, dl.LinkTail(node), "unlinked node can be linked again")
+}
+
+func TestDoubleLinkedList_Move(t *testing.T) {
+	dl := NewDoubleLinkedList[int]()
+	nodes := make([]*DoubleNode[int], 4)
+	for i := range nodes {
+		nodes[i] = NewDoubleNode(i)
+		_ = dl.LinkTail(nodes[i])
+	}
+	assert.NoError(t, dl.MoveToHead(nodes[2]))
+	assert.Equal(t, []int{2, 0, 1, 3}, listValues(dl))
+	assert.NoError(t, dl.MoveToTail(nodes[0]))
+	assert.Equal(t, []int{2, 1, 3, 0}, listValues(dl))
+	assert.NoError(t, dl.MoveToHead(nodes[2]))
+	assert.Equal(t, []int{2, 1, 3, 0}, listValues(dl))
+	assert.Equal(t, 4, dl.Length())
+	assert.Equal(t, 3, dl.Tail().Previous().Value())
+	assert.Equal(t, 1, dl.Head().Next().Value())
+	assert.ErrorIs(t, dl.MoveToHead(NewDoubleNode(5)), ErrNodeIsNotInList)
+}
@@ -0,0 +1,94 @@
+package roaring
+
+import "math/bits"
+
+// the operations return nil for an empty result and never change their arguments,
+// arrays are merged directly, anything else goes through bitmap words
+
+func and(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		return filter(x, b, true)
+	}
+	if y, ok := b.(*arrayContainer); ok {
+		return filter(y, a, true)
+	}
+	return words(a, b, func(x, y uint64) uint64 { return x & y })
+}
+
+func andNot(a, b container) container {
+	if x, ok := a.(*arrayContainer); ok {
+		return filter(x, b, false)
+	}
+	return words(a, b, func(x, y uint64) uint64 { return x &^ y })
+}
+
+func or(a, b container) container {
+	x, xok := a.(*arrayContainer)
+	y, yok := b.(*arrayContainer)
+	if xok && yok && len(x.values)+len(y.values) <= arrayMaxSize {
+		return mergeArrays(x, y, true)
+	}
+	return words(a, b, func(x, y uint64) uint64 { return x | y })
+}
+
+func xor(a, b container) container {
+	x, xok := a.(*arrayContainer)
+	y, yok := b.(*arrayContainer)
+	if xok && yok && len(x.values)+len(y.values) <= arrayMaxSize {
+		return mergeArrays(x, y, false)
+	}
+	return words(a, b, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// filter keeps the values of a which are in b when keep is true, or aren't otherwise
+func filter(a *arrayContainer, b container, keep bool) container {
+	var values []uint16
+	for _, v := range a.values {
+		if b.contains(v) == keep {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return &arrayContainer{values: values}
+}
+
+// mergeArrays keeps values of both arrays for a union or of only one of them otherwise
+func mergeArrays(a, b *arrayContainer, union bool) container {
+	values := make([]uint16, 0, len(a.values)+len(b.values))
+	i, j := 0, 0
+	for i < len(a.values) || j < len(b.values) {
+		switch {
+		case j == len(b.values) || (i < len(a.values) && a.values[i] < b.values[j]):
+			values = append(values, a.values[i])
+			i++
+		case i == len(a.values) || b.values[j] < a.values[i]:
+			values = append(values, b.values[j])
+			j++
+		default:
+			if union {
+				values = append(values, a.values[i])
+			}
+			i++
+			j++
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return &arrayContainer{values: values}
+}
+
+func words(a, b container, op func(x, y uint64) uint64) container {
+	x, y := a.toBitmap(), b.toBitmap()
+	r := &bitmapContainer{}
+	for i := range r.words {
+		r.words[i] = op(x.words[i], y.words[i])
+		r.card += bits.OnesCount64(r.words[i])
+	}
+	if r.card == 0 {
+		return nil
+	}
+	return normalize(r)
+}
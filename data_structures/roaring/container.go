@@ -0,0 +1,276 @@
+package roaring
+
+import (
+	"math/bits"
+	"slices"
+)
+
+const (
+	// arrayMaxSize is the largest array container, bigger ones are bitmaps
+	arrayMaxSize  = 4096
+	bitmapWords   = 1 << 16 / 64
+	bitmapBytes   = bitmapWords * 8
+	containerBits = 16
+)
+
+// container holds the low 16 bits of values sharing the same high 16 bits
+type container interface {
+	// add and remove return the container which replaces the receiver
+	add(x uint16) (container, bool)
+	remove(x uint16) (container, bool)
+	contains(x uint16) bool
+	cardinality() int
+	// rank returns the number of values less than or equal to x
+	rank(x uint16) int
+	selectAt(i int) uint16
+	iterate(yield func(uint16) bool) bool
+	clone() container
+	toBitmap() *bitmapContainer
+	// serializedSize is the payload size in the portable format
+	serializedSize() int
+}
+
+type arrayContainer struct {
+	values []uint16
+}
+
+func (c *arrayContainer) add(x uint16) (container, bool) {
+	i, found := slices.BinarySearch(c.values, x)
+	if found {
+		return c, false
+	}
+	if len(c.values) >= arrayMaxSize {
+		b := c.toBitmap()
+		b.add(x)
+		return b, true
+	}
+	c.values = slices.Insert(c.values, i, x)
+	return c, true
+}
+
+func (c *arrayContainer) remove(x uint16) (container, bool) {
+	i, found := slices.BinarySearch(c.values, x)
+	if !found {
+		return c, false
+	}
+	c.values = slices.Delete(c.values, i, i+1)
+	return c, true
+}
+
+func (c *arrayContainer) contains(x uint16) bool {
+	_, found := slices.BinarySearch(c.values, x)
+	return found
+}
+
+func (c *arrayContainer) cardinality() int {
+	return len(c.values)
+}
+
+func (c *arrayContainer) rank(x uint16) int {
+	i, found := slices.BinarySearch(c.values, x)
+	if found {
+		return i + 1
+	}
+	return i
+}
+
+func (c *arrayContainer) selectAt(i int) uint16 {
+	return c.values[i]
+}
+
+func (c *arrayContainer) iterate(yield func(uint16) bool) bool {
+	for _, v := range c.values {
+		if !yield(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *arrayContainer) clone() container {
+	return &arrayContainer{values: slices.Clone(c.values)}
+}
+
+func (c *arrayContainer) toBitmap() *bitmapContainer {
+	b := &bitmapContainer{card: len(c.values)}
+	for _, v := range c.values {
+		b.words[v/64] |= 1 << (v % 64)
+	}
+	return b
+}
+
+func (c *arrayContainer) serializedSize() int {
+	return 2 * len(c.values)
+}
+
+type bitmapContainer struct {
+	words [bitmapWords]uint64
+	card  int
+}
+
+func (c *bitmapContainer) add(x uint16) (container, bool) {
+	if c.contains(x) {
+		return c, false
+	}
+	c.words[x/64] |= 1 << (x % 64)
+	c.card++
+	return c, true
+}
+
+func (c *bitmapContainer) remove(x uint16) (container, bool) {
+	if !c.contains(x) {
+		return c, false
+	}
+	c.words[x/64] &^= 1 << (x % 64)
+	c.card--
+	if c.card <= arrayMaxSize {
+		return c.toArray(), true
+	}
+	return c, true
+}
+
+func (c *bitmapContainer) contains(x uint16) bool {
+	return c.words[x/64]&(1<<(x%64)) != 0
+}
+
+func (c *bitmapContainer) cardinality() int {
+	return c.card
+}
+
+func (c *bitmapContainer) rank(x uint16) int {
+	var n int
+	for _, w := range c.words[:x/64] {
+		n += bits.OnesCount64(w)
+	}
+	// the shift wraps to zero for the last bit, so the mask keeps the whole word
+	return n + bits.OnesCount64(c.words[x/64]&(2<<(x%64)-1))
+}
+
+func (c *bitmapContainer) selectAt(i int) uint16 {
+	for w, word := range c.words {
+		n := bits.OnesCount64(word)
+		if i >= n {
+			i -= n
+			continue
+		}
+		for ; i > 0; i-- {
+			word &= word - 1
+		}
+		return uint16(w*64 + bits.TrailingZeros64(word))
+	}
+	panic("roaring: select out of range")
+}
+
+func (c *bitmapContainer) iterate(yield func(uint16) bool) bool {
+	for w, word := range c.words {
+		for word != 0 {
+			if !yield(uint16(w*64 + bits.TrailingZeros64(word))) {
+				return false
+			}
+			word &= word - 1
+		}
+	}
+	return true
+}
+
+func (c *bitmapContainer) clone() container {
+	b := *c
+	return &b
+}
+
+func (c *bitmapContainer) toBitmap() *bitmapContainer {
+	return c
+}
+
+func (c *bitmapContainer) toArray() *arrayContainer {
+	a := &arrayContainer{values: make([]uint16, 0, c.card)}
+	c.iterate(func(v uint16) bool {
+		a.values = append(a.values, v)
+		return true
+	})
+	return a
+}
+
+func (c *bitmapContainer) serializedSize() int {
+	return bitmapBytes
+}
+
+// setRange sets the bits in [start, last]
+func (c *bitmapContainer) setRange(start, last uint16) {
+	for v := int(start); v <= int(last); {
+		w, bit := v/64, v%64
+		n := min(64-bit, int(last)-v+1)
+		mask := ^uint64(0) >> (64 - n) << bit
+		c.card += n - bits.OnesCount64(c.words[w]&mask)
+		c.words[w] |= mask
+		v += n
+	}
+}
+
+// countRuns returns the number of runs of consecutive values
+func countRuns(c container) int {
+	switch c := c.(type) {
+	case *runContainer:
+		return len(c.runs)
+	case *bitmapContainer:
+		var runs int
+		var carry uint64
+		for _, w := range c.words {
+			runs += bits.OnesCount64(w &^ (w<<1 | carry))
+			carry = w >> 63
+		}
+		return runs
+	}
+	var runs int
+	prev := -2
+	c.iterate(func(v uint16) bool {
+		if int(v) != prev+1 {
+			runs++
+		}
+		prev = int(v)
+		return true
+	})
+	return runs
+}
+
+// optimize picks the representation with the smallest serialized size
+func optimize(c container) container {
+	card := c.cardinality()
+	runSize := 2 + 4*countRuns(c)
+	size := bitmapBytes
+	if card <= arrayMaxSize {
+		size = 2 * card
+	}
+	if runSize < size {
+		if _, ok := c.(*runContainer); ok {
+			return c
+		}
+		return toRun(c)
+	}
+	return normalize(c)
+}
+
+// normalize turns c into an array or a bitmap depending on its cardinality
+func normalize(c container) container {
+	switch c := c.(type) {
+	case *arrayContainer:
+		if len(c.values) > arrayMaxSize {
+			return c.toBitmap()
+		}
+		return c
+	case *bitmapContainer:
+		if c.card <= arrayMaxSize {
+			return c.toArray()
+		}
+		return c
+	}
+	if c.cardinality() <= arrayMaxSize {
+		a := &arrayContainer{values: make([]uint16, 0, c.cardinality())}
+		c.iterate(func(v uint16) bool {
+			a.values = append(a.values, v)
+			return true
+		})
+		return a
+	}
+	return c.toBitmap()
+}
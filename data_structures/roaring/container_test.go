@@ -0,0 +1,82 @@
+package roaring
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestContainers_Equivalent(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	want := make(map[uint16]bool)
+	var array container = &arrayContainer{}
+	var bitmap container = &bitmapContainer{}
+	var run container = &runContainer{}
+	for i := 0; i < 30000; i++ {
+		x := uint16(rnd.Intn(12000))
+		if rnd.Intn(4) == 0 {
+			var removed [3]bool
+			array, removed[0] = array.remove(x)
+			bitmap, removed[1] = bitmap.remove(x)
+			run, removed[2] = run.remove(x)
+			assert.Equal(t, [3]bool{want[x], want[x], want[x]}, removed)
+			delete(want, x)
+			continue
+		}
+		var added [3]bool
+		array, added[0] = array.add(x)
+		bitmap, added[1] = bitmap.add(x)
+		run, added[2] = run.add(x)
+		assert.Equal(t, [3]bool{!want[x], !want[x], !want[x]}, added)
+		want[x] = true
+	}
+	for _, c := range []container{array, bitmap, run} {
+		assert.Equal(t, len(want), c.cardinality())
+		rank := 0
+		for x := 0; x < 1<<16; x++ {
+			if want[uint16(x)] {
+				assert.Equal(t, uint16(x), c.selectAt(rank))
+				rank++
+			}
+			assert.Equal(t, want[uint16(x)], c.contains(uint16(x)))
+			assert.Equal(t, rank, c.rank(uint16(x)))
+		}
+	}
+}
+
+func TestRunContainer_Edges(t *testing.T) {
+	var c container = &runContainer{runs: []interval{{start: 0, last: 0xffff}}}
+	assert.Equal(t, 1<<16, c.cardinality())
+	assert.Equal(t, 1<<16, c.rank(0xffff))
+	assert.Equal(t, uint16(0xffff), c.selectAt(0xffff))
+
+	c, _ = c.remove(0xffff)
+	c, _ = c.remove(0)
+	assert.Equal(t, []interval{{start: 1, last: 0xfffe}}, c.(*runContainer).runs)
+	c, _ = c.remove(100)
+	assert.Equal(t, []interval{{start: 1, last: 99}, {start: 101, last: 0xfffe}}, c.(*runContainer).runs)
+	c, _ = c.add(100)
+	c, _ = c.add(0)
+	c, _ = c.add(0xffff)
+	assert.Equal(t, []interval{{start: 0, last: 0xffff}}, c.(*runContainer).runs, "adjacent runs must be merged")
+}
+
+func TestOptimize(t *testing.T) {
+	tests := []struct {
+		name string
+		c    container
+		want container
+	}{
+		{name: "sparse array stays", c: &arrayContainer{values: []uint16{1, 3, 5}}, want: &arrayContainer{}},
+		{name: "consecutive array becomes runs", c: &arrayContainer{values: []uint16{1, 2, 3, 4, 5}}, want: &runContainer{}},
+		{name: "full bitmap becomes a run", c: (&runContainer{runs: []interval{{start: 0, last: 0xffff}}}).toBitmap(), want: &runContainer{}},
+		{name: "scattered runs become an array", c: toRun(&arrayContainer{values: []uint16{1, 3, 5}}), want: &arrayContainer{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := optimize(tt.c)
+			assert.IsType(t, tt.want, got)
+			assert.Equal(t, tt.c.cardinality(), got.cardinality())
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package roaring
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"slices"
+)
+
+// The binary form follows the Roaring format specification shared by the C, Java and Go libraries:
+// a cookie, a key and cardinality-1 pair per container, offsets of the containers
+// unless there are run containers and fewer than noOffsetThreshold of them, then the containers.
+// Arrays hold little endian uint16 values, bitmaps 1024 little endian uint64 words,
+// runs their count followed by start and length-1 pairs
+const (
+	serialCookieNoRun = 12346
+	serialCookie      = 12347
+	noOffsetThreshold = 4
+)
+
+var ErrInvalidData = errors.New("invalid bitmap data")
+
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	n := len(b.containers)
+	hasRun := slices.ContainsFunc(b.containers, func(c container) bool {
+		_, ok := c.(*runContainer)
+		return ok
+	})
+
+	var data []byte
+	if hasRun {
+		data = binary.LittleEndian.AppendUint32(data, serialCookie|uint32(n-1)<<16)
+		runs := make([]byte, (n+7)/8)
+		for i, c := range b.containers {
+			if _, ok := c.(*runContainer); ok {
+				runs[i/8] |= 1 << (i % 8)
+			}
+		}
+		data = append(data, runs...)
+	} else {
+		data = binary.LittleEndian.AppendUint32(data, serialCookieNoRun)
+		data = binary.LittleEndian.AppendUint32(data, uint32(n))
+	}
+	for i, c := range b.containers {
+		data = binary.LittleEndian.AppendUint16(data, b.keys[i])
+		data = binary.LittleEndian.AppendUint16(data, uint16(c.cardinality()-1))
+	}
+	if !hasRun || n >= noOffsetThreshold {
+		offset := len(data) + 4*n
+		for _, c := range b.containers {
+			data = binary.LittleEndian.AppendUint32(data, uint32(offset))
+			offset += c.serializedSize()
+		}
+	}
+
+	for _, c := range b.containers {
+		switch c := c.(type) {
+		case *arrayContainer:
+			for _, v := range c.values {
+				data = binary.LittleEndian.AppendUint16(data, v)
+			}
+		case *bitmapContainer:
+			for _, w := range c.words {
+				data = binary.LittleEndian.AppendUint64(data, w)
+			}
+		case *runContainer:
+			data = binary.LittleEndian.AppendUint16(data, uint16(len(c.runs)))
+			for _, r := range c.runs {
+				data = binary.LittleEndian.AppendUint16(data, r.start)
+				data = binary.LittleEndian.AppendUint16(data, r.last-r.start)
+			}
+		}
+	}
+	return data, nil
+}
+
+// reader consumes little endian values and remembers running out of data
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) next(size int) []byte {
+	if r.err != nil || len(r.data) < size {
+		r.err = ErrInvalidData
+		return nil
+	}
+	b := r.data[:size]
+	r.data = r.data[size:]
+	return b
+}
+
+func (r *reader) uint16() uint16 {
+	if b := r.next(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *reader) uint32() uint32 {
+	if b := r.next(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	r := &reader{data: data}
+	cookie := r.uint32()
+	var n int
+	var runs []byte
+	switch {
+	case r.err != nil:
+		return r.err
+	case cookie&0xffff == serialCookie:
+		n = int(cookie>>16) + 1
+		runs = r.next((n + 7) / 8)
+	case cookie == serialCookieNoRun:
+		n = int(r.uint32())
+	default:
+		return ErrInvalidData
+	}
+	if r.err != nil || n > 1<<16 || len(r.data) < 4*n {
+		return ErrInvalidData
+	}
+
+	keys := make([]uint16, n)
+	cards := make([]int, n)
+	for i := range keys {
+		keys[i] = r.uint16()
+		cards[i] = int(r.uint16()) + 1
+		if i > 0 && keys[i] <= keys[i-1] {
+			return ErrInvalidData
+		}
+	}
+	if runs == nil || n >= noOffsetThreshold {
+		r.next(4 * n)
+	}
+
+	containers := make([]container, n)
+	for i := range containers {
+		var c container
+		switch {
+		case runs != nil && runs[i/8]&(1<<(i%8)) != 0:
+			c = readRuns(r)
+		case cards[i] > arrayMaxSize:
+			c = readBitmap(r)
+		default:
+			c = readArray(r, cards[i])
+		}
+		if r.err != nil || c == nil || c.cardinality() != cards[i] {
+			return ErrInvalidData
+		}
+		containers[i] = c
+	}
+	if len(r.data) != 0 {
+		return ErrInvalidData
+	}
+	b.keys, b.containers = keys, containers
+	return nil
+}
+
+func readArray(r *reader, card int) container {
+	c := &arrayContainer{values: make([]uint16, card)}
+	for i := range c.values {
+		c.values[i] = r.uint16()
+		if i > 0 && c.values[i] <= c.values[i-1] {
+			return nil
+		}
+	}
+	return c
+}
+
+func readBitmap(r *reader) container {
+	c := &bitmapContainer{}
+	for i := range c.words {
+		if b := r.next(8); b != nil {
+			c.words[i] = binary.LittleEndian.Uint64(b)
+			c.card += bits.OnesCount64(c.words[i])
+		}
+	}
+	return c
+}
+
+// readRuns rejects runs which overflow, overlap or touch, the latter must be one run
+func readRuns(r *reader) container {
+	c := &runContainer{runs: make([]interval, r.uint16())}
+	for i := range c.runs {
+		start, length := r.uint16(), r.uint16()
+		if int(start)+int(length) > 0xffff || (i > 0 && int(start) <= int(c.runs[i-1].last)+1) {
+			return nil
+		}
+		c.runs[i] = interval{start: start, last: start + length}
+	}
+	if len(c.runs) == 0 {
+		return nil
+	}
+	return c
+}
@@ -0,0 +1,98 @@
+package roaring
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+func TestBitmap_MarshalBinary_Format(t *testing.T) {
+	tests := []struct {
+		name   string
+		bitmap func() *Bitmap
+		want   []byte
+	}{
+		{
+			name:   "empty",
+			bitmap: func() *Bitmap { return NewBitmap() },
+			want:   []byte{0x3a, 0x30, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:   "array",
+			bitmap: func() *Bitmap { return NewBitmap(1, 3, 1<<16) },
+			want: []byte{
+				0x3a, 0x30, 0, 0, 2, 0, 0, 0,
+				0, 0, 1, 0, 1, 0, 0, 0,
+				24, 0, 0, 0, 28, 0, 0, 0,
+				1, 0, 3, 0, 0, 0,
+			},
+		},
+		{
+			name: "run",
+			bitmap: func() *Bitmap {
+				b := NewBitmap()
+				b.AddRange(5, 9)
+				return b
+			},
+			want: []byte{
+				0x3b, 0x30, 0, 0, 1,
+				0, 0, 4, 0,
+				1, 0, 5, 0, 4, 0,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.bitmap()
+			got, err := b.MarshalBinary()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			decoded := NewBitmap(42)
+			assert.NoError(t, decoded.UnmarshalBinary(got))
+			assert.True(t, b.Equal(decoded))
+		})
+	}
+}
+
+func TestBitmap_MarshalBinary_RoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	b := NewBitmap(randomValues(rnd)...)
+	for _, optimized := range []bool{false, true} {
+		if optimized {
+			b.RunOptimize()
+		}
+		data, err := b.MarshalBinary()
+		assert.NoError(t, err)
+		decoded := NewBitmap()
+		assert.NoError(t, decoded.UnmarshalBinary(data))
+		assert.Equal(t, b.Slice(), decoded.Slice())
+		for i, c := range b.containers {
+			assert.IsType(t, c, decoded.containers[i])
+		}
+	}
+}
+
+func TestBitmap_UnmarshalBinary_Invalid(t *testing.T) {
+	valid, _ := NewBitmap(1, 3, 1<<16).MarshalBinary()
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "unknown cookie", data: []byte{1, 2, 3, 4, 0, 0, 0, 0}},
+		{name: "truncated", data: valid[:len(valid)-1]},
+		{name: "trailing bytes", data: append(append([]byte(nil), valid...), 0)},
+		{name: "unsorted keys", data: []byte{0x3a, 0x30, 0, 0, 2, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 24, 0, 0, 0, 26, 0, 0, 0, 1, 0, 1, 0}},
+		{name: "unsorted array", data: []byte{0x3a, 0x30, 0, 0, 1, 0, 0, 0, 0, 0, 1, 0, 16, 0, 0, 0, 3, 0, 1, 0}},
+		{name: "overflowing run", data: []byte{0x3b, 0x30, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0xff, 0xff, 1, 0}},
+		{name: "wrong cardinality", data: []byte{0x3b, 0x30, 0, 0, 1, 0, 0, 5, 0, 1, 0, 5, 0, 4, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBitmap(7)
+			assert.ErrorIs(t, b.UnmarshalBinary(tt.data), ErrInvalidData)
+			assert.Equal(t, []uint32{7}, b.Slice(), "failed decoding must keep the bitmap")
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package roaring
+
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func split(x uint32) (uint16, uint16) {
+	return uint16(x >> containerBits), uint16(x)
+}
+
+func join(hi, lo uint16) uint32 {
+	return uint32(hi)<<containerBits | uint32(lo)
+}
+
+// Bitmap is a compressed set of uint32 values. Values are grouped by their high 16 bits,
+// every group keeps the low bits in a sorted array, a 2^16 bit bitmap or a list of runs,
+// whichever is smaller
+type Bitmap struct {
+	keys       []uint16
+	containers []container
+}
+
+func (b *Bitmap) find(hi uint16) (int, bool) {
+	return slices.BinarySearch(b.keys, hi)
+}
+
+func (b *Bitmap) Add(x uint32) bool {
+	hi, lo := split(x)
+	i, found := b.find(hi)
+	if !found {
+		b.keys = slices.Insert(b.keys, i, hi)
+		b.containers = slices.Insert(b.containers, i, container(&arrayContainer{}))
+	}
+	var added bool
+	b.containers[i], added = b.containers[i].add(lo)
+	return added
+}
+
+// AddRange adds the values in [start, last] as runs
+func (b *Bitmap) AddRange(start, last uint32) {
+	for lo := uint64(start); lo <= uint64(last); {
+		hi, low := split(uint32(lo))
+		high := min(uint64(last), uint64(join(hi, 0xffff)))
+		r := &runContainer{runs: []interval{{start: low, last: uint16(high)}}}
+		i, found := b.find(hi)
+		if found {
+			b.containers[i] = optimize(or(b.containers[i], r))
+		} else {
+			b.keys = slices.Insert(b.keys, i, hi)
+			b.containers = slices.Insert(b.containers, i, optimize(r))
+		}
+		lo = high + 1
+	}
+}
+
+var ErrValueIsNotFound = errors.New("value is not found")
+
+func (b *Bitmap) Remove(x uint32) error {
+	if !b.Discard(x) {
+		return ErrValueIsNotFound
+	}
+	return nil
+}
+
+func (b *Bitmap) Discard(x uint32) bool {
+	hi, lo := split(x)
+	i, found := b.find(hi)
+	if !found {
+		return false
+	}
+	var removed bool
+	b.containers[i], removed = b.containers[i].remove(lo)
+	if b.containers[i].cardinality() == 0 {
+		b.keys = slices.Delete(b.keys, i, i+1)
+		b.containers = slices.Delete(b.containers, i, i+1)
+	}
+	return removed
+}
+
+func (b *Bitmap) Contains(x uint32) bool {
+	hi, lo := split(x)
+	i, found := b.find(hi)
+	return found && b.containers[i].contains(lo)
+}
+
+func (b *Bitmap) Cardinality() uint64 {
+	var n uint64
+	for _, c := range b.containers {
+		n += uint64(c.cardinality())
+	}
+	return n
+}
+
+func (b *Bitmap) IsEmpty() bool {
+	return len(b.keys) == 0
+}
+
+func (b *Bitmap) RemoveAll() {
+	b.keys, b.containers = nil, nil
+}
+
+var ErrBitmapIsEmpty = errors.New("bitmap is empty")
+
+func (b *Bitmap) Min() (uint32, error) {
+	if b.IsEmpty() {
+		return 0, ErrBitmapIsEmpty
+	}
+	return join(b.keys[0], b.containers[0].selectAt(0)), nil
+}
+
+func (b *Bitmap) Max() (uint32, error) {
+	if b.IsEmpty() {
+		return 0, ErrBitmapIsEmpty
+	}
+	last := len(b.keys) - 1
+	c := b.containers[last]
+	return join(b.keys[last], c.selectAt(c.cardinality()-1)), nil
+}
+
+// Rank returns the number of values less than or equal to x
+func (b *Bitmap) Rank(x uint32) uint64 {
+	hi, lo := split(x)
+	var rank uint64
+	for i, key := range b.keys {
+		if key > hi {
+			break
+		}
+		if key < hi {
+			rank += uint64(b.containers[i].cardinality())
+			continue
+		}
+		rank += uint64(b.containers[i].rank(lo))
+	}
+	return rank
+}
+
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+// Select returns the value with the given rank, starting from 0
+func (b *Bitmap) Select(i uint64) (uint32, error) {
+	for j, c := range b.containers {
+		card := uint64(c.cardinality())
+		if i < card {
+			return join(b.keys[j], c.selectAt(int(i))), nil
+		}
+		i -= card
+	}
+	return 0, ErrIndexOutOfRange
+}
+
+// All iterates over the values in ascending order
+func (b *Bitmap) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		for i, c := range b.containers {
+			hi := b.keys[i]
+			if !c.iterate(func(lo uint16) bool { return yield(join(hi, lo)) }) {
+				return
+			}
+		}
+	}
+}
+
+func (b *Bitmap) Slice() []uint32 {
+	values := make([]uint32, 0, b.Cardinality())
+	for x := range b.All() {
+		values = append(values, x)
+	}
+	return values
+}
+
+func (b *Bitmap) String() string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for x := range b.All() {
+		if sb.Len() > 1 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(strconv.FormatUint(uint64(x), 10))
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// RunOptimize converts every container to its smallest representation,
+// algebra results and single adds never create run containers on their own
+func (b *Bitmap) RunOptimize() {
+	for i, c := range b.containers {
+		b.containers[i] = optimize(c)
+	}
+}
+
+func (b *Bitmap) Clone() *Bitmap {
+	c := &Bitmap{keys: slices.Clone(b.keys), containers: make([]container, len(b.containers))}
+	for i, ct := range b.containers {
+		c.containers[i] = ct.clone()
+	}
+	return c
+}
+
+func (b *Bitmap) Equal(other *Bitmap) bool {
+	if !slices.Equal(b.keys, other.keys) {
+		return false
+	}
+	for i, c := range b.containers {
+		if c.cardinality() != other.containers[i].cardinality() || xor(c, other.containers[i]) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *Bitmap) IsSubset(other *Bitmap) bool {
+	for i, key := range b.keys {
+		j, found := other.find(key)
+		if !found || andNot(b.containers[i], other.containers[j]) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// merge walks the keys of both bitmaps at once, op combines containers with the same key
+// and a nil result drops the key, keepLeft and keepRight tell whether unmatched containers stay
+func (b *Bitmap) merge(other *Bitmap, op func(a, b container) container, keepLeft, keepRight bool) *Bitmap {
+	r := &Bitmap{}
+	appendContainer := func(key uint16, c container) {
+		if c != nil {
+			r.keys = append(r.keys, key)
+			r.containers = append(r.containers, c)
+		}
+	}
+	i, j := 0, 0
+	for i < len(b.keys) || j < len(other.keys) {
+		switch {
+		case j == len(other.keys) || (i < len(b.keys) && b.keys[i] < other.keys[j]):
+			if keepLeft {
+				appendContainer(b.keys[i], b.containers[i].clone())
+			}
+			i++
+		case i == len(b.keys) || other.keys[j] < b.keys[i]:
+			if keepRight {
+				appendContainer(other.keys[j], other.containers[j].clone())
+			}
+			j++
+		default:
+			appendContainer(b.keys[i], op(b.containers[i], other.containers[j]))
+			i++
+			j++
+		}
+	}
+	return r
+}
+
+func (b *Bitmap) Union(other *Bitmap) *Bitmap {
+	return b.merge(other, or, true, true)
+}
+
+func (b *Bitmap) Intersect(other *Bitmap) *Bitmap {
+	return b.merge(other, and, false, false)
+}
+
+func (b *Bitmap) Difference(other *Bitmap) *Bitmap {
+	return b.merge(other, andNot, true, false)
+}
+
+func (b *Bitmap) SymmetricDifference(other *Bitmap) *Bitmap {
+	return b.merge(other, xor, true, true)
+}
+
+func NewBitmap(values ...uint32) *Bitmap {
+	b := &Bitmap{}
+	for _, x := range values {
+		b.Add(x)
+	}
+	return b
+}
@@ -0,0 +1,210 @@
+package roaring
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// randomValues mixes sparse values, dense blocks and long runs so all containers are used
+func randomValues(rnd *rand.Rand) []uint32 {
+	var values []uint32
+	for i := 0; i < 2000; i++ {
+		values = append(values, rnd.Uint32())
+	}
+	for i := 0; i < 10000; i++ {
+		values = append(values, 1<<16+uint32(rnd.Intn(1<<15)))
+	}
+	for x := uint32(5 << 16); x < 5<<16+30000; x++ {
+		values = append(values, x)
+	}
+	return values
+}
+
+func sortedDistinct(values []uint32) []uint32 {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return slices.Compact(values)
+}
+
+func TestBitmap_AddRemoveContains(t *testing.T) {
+	b := NewBitmap()
+	assert.True(t, b.IsEmpty())
+	assert.True(t, b.Add(1))
+	assert.False(t, b.Add(1))
+	assert.True(t, b.Add(1<<20))
+	assert.True(t, b.Add(^uint32(0)))
+	assert.Equal(t, uint64(3), b.Cardinality())
+	assert.True(t, b.Contains(1<<20))
+	assert.False(t, b.Contains(2))
+
+	assert.NoError(t, b.Remove(1<<20))
+	assert.ErrorIs(t, b.Remove(1<<20), ErrValueIsNotFound)
+	assert.False(t, b.Discard(3))
+	assert.Len(t, b.keys, 2, "empty containers must be dropped")
+	assert.Equal(t, "{1, 4294967295}", b.String())
+
+	b.RemoveAll()
+	assert.True(t, b.IsEmpty())
+	assert.Equal(t, "{}", b.String())
+}
+
+func TestBitmap_Containers(t *testing.T) {
+	b := NewBitmap()
+	for x := uint32(0); x < arrayMaxSize; x++ {
+		b.Add(x * 2)
+	}
+	assert.IsType(t, &arrayContainer{}, b.containers[0])
+	b.Add(1)
+	assert.IsType(t, &bitmapContainer{}, b.containers[0], "array must turn into a bitmap past its limit")
+	b.Discard(1)
+	assert.IsType(t, &arrayContainer{}, b.containers[0], "bitmap must turn back into an array")
+
+	b.RemoveAll()
+	b.AddRange(10, 70000)
+	assert.IsType(t, &runContainer{}, b.containers[0])
+	assert.IsType(t, &runContainer{}, b.containers[1])
+	assert.Equal(t, uint64(69991), b.Cardinality())
+	for x := uint32(20); x < 70000; x += 2 {
+		b.Discard(x)
+	}
+	assert.IsType(t, &bitmapContainer{}, b.containers[0], "many short runs must be converted")
+	assert.Equal(t, uint64(15), b.Rank(29))
+	assert.False(t, b.Contains(20))
+	assert.True(t, b.Contains(21))
+}
+
+func TestBitmap_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	values := randomValues(rnd)
+	want := make(map[uint32]bool)
+	b := NewBitmap()
+	for _, x := range values {
+		assert.Equal(t, !want[x], b.Add(x))
+		want[x] = true
+	}
+	for i := 0; i < 5000; i++ {
+		x := values[rnd.Intn(len(values))]
+		assert.Equal(t, want[x], b.Discard(x))
+		delete(want, x)
+	}
+	for _, optimized := range []bool{false, true} {
+		if optimized {
+			b.RunOptimize()
+		}
+		assert.Equal(t, uint64(len(want)), b.Cardinality())
+		for _, x := range values {
+			assert.Equal(t, want[x], b.Contains(x))
+		}
+		var sorted []uint32
+		for x := range want {
+			sorted = append(sorted, x)
+		}
+		assert.Equal(t, sortedDistinct(sorted), b.Slice())
+	}
+}
+
+func TestBitmap_RankSelect(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	b := NewBitmap(randomValues(rnd)...)
+	b.RunOptimize()
+	sorted := b.Slice()
+	for i := 0; i < 2000; i++ {
+		j := rnd.Intn(len(sorted))
+		got, err := b.Select(uint64(j))
+		assert.NoError(t, err)
+		assert.Equal(t, sorted[j], got)
+		assert.Equal(t, uint64(j+1), b.Rank(sorted[j]))
+		x := rnd.Uint32()
+		want, _ := slices.BinarySearch(sorted, x+1)
+		if x == ^uint32(0) {
+			want = len(sorted)
+		}
+		assert.Equal(t, uint64(want), b.Rank(x))
+	}
+	_, err := b.Select(uint64(len(sorted)))
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+
+	minimum, _ := b.Min()
+	maximum, _ := b.Max()
+	assert.Equal(t, sorted[0], minimum)
+	assert.Equal(t, sorted[len(sorted)-1], maximum)
+	_, err = NewBitmap().Min()
+	assert.ErrorIs(t, err, ErrBitmapIsEmpty)
+	_, err = NewBitmap().Max()
+	assert.ErrorIs(t, err, ErrBitmapIsEmpty)
+}
+
+func TestBitmap_Algebra(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	left, right := randomValues(rnd), randomValues(rnd)
+	inLeft, inRight := make(map[uint32]bool), make(map[uint32]bool)
+	for _, x := range left {
+		inLeft[x] = true
+	}
+	for _, x := range right {
+		inRight[x] = true
+	}
+	expected := func(keep func(l, r bool) bool) []uint32 {
+		var values []uint32
+		for _, x := range sortedDistinct(append(slices.Clone(left), right...)) {
+			if keep(inLeft[x], inRight[x]) {
+				values = append(values, x)
+			}
+		}
+		return values
+	}
+
+	a, b := NewBitmap(left...), NewBitmap(right...)
+	b.RunOptimize()
+	tests := []struct {
+		name string
+		got  *Bitmap
+		want []uint32
+	}{
+		{name: "union", got: a.Union(b), want: expected(func(l, r bool) bool { return l || r })},
+		{name: "intersect", got: a.Intersect(b), want: expected(func(l, r bool) bool { return l && r })},
+		{name: "difference", got: a.Difference(b), want: expected(func(l, r bool) bool { return l && !r })},
+		{name: "symmetric difference", got: a.SymmetricDifference(b), want: expected(func(l, r bool) bool { return l != r })},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got.Slice())
+			assert.Equal(t, uint64(len(tt.want)), tt.got.Cardinality())
+		})
+	}
+	assert.Equal(t, sortedDistinct(left), a.Slice(), "operands must not change")
+	assert.Equal(t, sortedDistinct(right), b.Slice(), "operands must not change")
+}
+
+func TestBitmap_Predicates(t *testing.T) {
+	a := NewBitmap(1, 2, 3, 1<<20)
+	b := a.Clone()
+	assert.True(t, a.Equal(b))
+	b.RunOptimize()
+	assert.True(t, a.Equal(b), "representation must not matter")
+	assert.True(t, a.IsSubset(b))
+
+	b.Add(4)
+	assert.False(t, a.Equal(b))
+	assert.True(t, a.IsSubset(b))
+	assert.False(t, b.IsSubset(a))
+	assert.True(t, NewBitmap().IsSubset(a))
+
+	a.Add(5)
+	assert.False(t, b.Contains(5), "clone must be independent")
+}
+
+func TestBitmap_AllStops(t *testing.T) {
+	b := NewBitmap()
+	b.AddRange(0, 100)
+	var got []uint32
+	for x := range b.All() {
+		if x == 3 {
+			break
+		}
+		got = append(got, x)
+	}
+	assert.Equal(t, []uint32{0, 1, 2}, got)
+}
@@ -0,0 +1,148 @@
+package roaring
+
+import (
+	"slices"
+	"sort"
+)
+
+// interval is an inclusive range of values
+type interval struct {
+	start uint16
+	last  uint16
+}
+
+func (r interval) length() int {
+	return int(r.last) - int(r.start) + 1
+}
+
+type runContainer struct {
+	runs []interval
+}
+
+func toRun(c container) *runContainer {
+	r := &runContainer{}
+	c.iterate(func(v uint16) bool {
+		if n := len(r.runs); n > 0 && int(r.runs[n-1].last)+1 == int(v) {
+			r.runs[n-1].last = v
+		} else {
+			r.runs = append(r.runs, interval{start: v, last: v})
+		}
+		return true
+	})
+	return r
+}
+
+// search returns the index of the first run ending at x or after it
+func (c *runContainer) search(x uint16) int {
+	return sort.Search(len(c.runs), func(i int) bool {
+		return c.runs[i].last >= x
+	})
+}
+
+func (c *runContainer) add(x uint16) (container, bool) {
+	i := c.search(x)
+	if i < len(c.runs) && c.runs[i].start <= x {
+		return c, false
+	}
+	mergePrev := i > 0 && c.runs[i-1].last+1 == x
+	mergeNext := i < len(c.runs) && c.runs[i].start-1 == x
+	switch {
+	case mergePrev && mergeNext:
+		c.runs[i-1].last = c.runs[i].last
+		c.runs = slices.Delete(c.runs, i, i+1)
+	case mergePrev:
+		c.runs[i-1].last = x
+	case mergeNext:
+		c.runs[i].start = x
+	default:
+		c.runs = slices.Insert(c.runs, i, interval{start: x, last: x})
+		// a new run may make other representations smaller
+		return optimize(c), true
+	}
+	return c, true
+}
+
+func (c *runContainer) remove(x uint16) (container, bool) {
+	i := c.search(x)
+	if i == len(c.runs) || c.runs[i].start > x {
+		return c, false
+	}
+	r := c.runs[i]
+	switch {
+	case r.start == r.last:
+		c.runs = slices.Delete(c.runs, i, i+1)
+	case x == r.start:
+		c.runs[i].start++
+	case x == r.last:
+		c.runs[i].last--
+	default:
+		c.runs[i].last = x - 1
+		c.runs = slices.Insert(c.runs, i+1, interval{start: x + 1, last: r.last})
+		return optimize(c), true
+	}
+	return c, true
+}
+
+func (c *runContainer) contains(x uint16) bool {
+	i := c.search(x)
+	return i < len(c.runs) && c.runs[i].start <= x
+}
+
+func (c *runContainer) cardinality() int {
+	var n int
+	for _, r := range c.runs {
+		n += r.length()
+	}
+	return n
+}
+
+func (c *runContainer) rank(x uint16) int {
+	var n int
+	for _, r := range c.runs {
+		if r.start > x {
+			break
+		}
+		n += int(min(r.last, x)) - int(r.start) + 1
+	}
+	return n
+}
+
+func (c *runContainer) selectAt(i int) uint16 {
+	for _, r := range c.runs {
+		if i < r.length() {
+			return r.start + uint16(i)
+		}
+		i -= r.length()
+	}
+	panic("roaring: select out of range")
+}
+
+func (c *runContainer) iterate(yield func(uint16) bool) bool {
+	for _, r := range c.runs {
+		for v := r.start; ; v++ {
+			if !yield(v) {
+				return false
+			}
+			if v == r.last {
+				break
+			}
+		}
+	}
+	return true
+}
+
+func (c *runContainer) clone() container {
+	return &runContainer{runs: slices.Clone(c.runs)}
+}
+
+func (c *runContainer) toBitmap() *bitmapContainer {
+	b := &bitmapContainer{}
+	for _, r := range c.runs {
+		b.setRange(r.start, r.last)
+	}
+	return b
+}
+
+func (c *runContainer) serializedSize() int {
+	return 2 + 4*len(c.runs)
+}
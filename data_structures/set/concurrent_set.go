@@ -0,0 +1,137 @@
+package set
+
+import (
+	"iter"
+	"sync"
+)
+
+// ConcurrentSet guards a Set with a RWMutex.
+// Set operations copy both operands under their own read locks first,
+// so they never hold two locks and see every operand at a single moment
+type ConcurrentSet[T comparable] struct {
+	mu  sync.RWMutex
+	set Set[T]
+}
+
+// AddIfAbsent reports whether value was added, the check and the insert happen under one lock
+func (s *ConcurrentSet[T]) AddIfAbsent(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Add(value)
+}
+
+func (s *ConcurrentSet[T]) Add(value T) bool {
+	return s.AddIfAbsent(value)
+}
+
+// AddAll returns the number of added values
+func (s *ConcurrentSet[T]) AddAll(values ...T) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var added int
+	for _, value := range values {
+		if s.set.Add(value) {
+			added++
+		}
+	}
+	return added
+}
+
+func (s *ConcurrentSet[T]) Remove(value T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Remove(value)
+}
+
+func (s *ConcurrentSet[T]) Discard(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Discard(value)
+}
+
+// RemoveAll returns the number of removed values, use Clear to remove everything
+func (s *ConcurrentSet[T]) RemoveAll(values ...T) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var removed int
+	for _, value := range values {
+		if s.set.Discard(value) {
+			removed++
+		}
+	}
+	return removed
+}
+
+func (s *ConcurrentSet[T]) Pop() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.set.Pop()
+}
+
+func (s *ConcurrentSet[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set.RemoveAll()
+}
+
+func (s *ConcurrentSet[T]) Contains(value T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Contains(value)
+}
+
+func (s *ConcurrentSet[T]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Size()
+}
+
+func (s *ConcurrentSet[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Snapshot returns a copy which isn't affected by later changes
+func (s *ConcurrentSet[T]) Snapshot() *Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Clone()
+}
+
+// All iterates over a snapshot, so yield may change s
+func (s *ConcurrentSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for value := range *s.Snapshot() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+func (s *ConcurrentSet[T]) Union(other *ConcurrentSet[T]) *ConcurrentSet[T] {
+	return &ConcurrentSet[T]{set: *s.Snapshot().Union(other.Snapshot())}
+}
+
+func (s *ConcurrentSet[T]) Intersect(other *ConcurrentSet[T]) *ConcurrentSet[T] {
+	return &ConcurrentSet[T]{set: *s.Snapshot().Intersect(other.Snapshot())}
+}
+
+func (s *ConcurrentSet[T]) Difference(other *ConcurrentSet[T]) *ConcurrentSet[T] {
+	return &ConcurrentSet[T]{set: *s.Snapshot().Difference(other.Snapshot())}
+}
+
+func (s *ConcurrentSet[T]) SymmetricDifference(other *ConcurrentSet[T]) *ConcurrentSet[T] {
+	return &ConcurrentSet[T]{set: *s.Snapshot().SymmetricDifference(other.Snapshot())}
+}
+
+func (s *ConcurrentSet[T]) IsSubset(other *ConcurrentSet[T]) bool {
+	return s.Snapshot().IsSubset(other.Snapshot())
+}
+
+func (s *ConcurrentSet[T]) Equal(other *ConcurrentSet[T]) bool {
+	return s.Snapshot().Equal(other.Snapshot())
+}
+
+func NewConcurrentSet[T comparable](values ...T) *ConcurrentSet[T] {
+	return &ConcurrentSet[T]{set: *NewSet(values...)}
+}
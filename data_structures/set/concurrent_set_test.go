@@ -0,0 +1,109 @@
+package set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+const (
+	workers    = 16
+	iterations = 1000
+)
+
+func TestConcurrentSet_AddIfAbsent(t *testing.T) {
+	s := NewConcurrentSet[int]()
+	var added atomic.Int32
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				if s.AddIfAbsent(i) {
+					added.Add(1)
+				}
+				assert.True(t, s.Contains(i))
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(iterations), added.Load(), "every value must be added exactly once")
+	assert.Equal(t, iterations, s.Size())
+}
+
+func TestConcurrentSet_Batch(t *testing.T) {
+	s := NewConcurrentSet(1, 2)
+	assert.Equal(t, 2, s.AddAll(2, 3, 4))
+	assert.Equal(t, 2, s.RemoveAll(1, 4, 5))
+	assert.ElementsMatch(t, []int{2, 3}, keys(s.Snapshot()))
+
+	assert.False(t, s.Add(2))
+	assert.NoError(t, s.Remove(2))
+	assert.ErrorIs(t, s.Remove(2), ErrValueIsNotFound)
+	assert.True(t, s.Discard(3))
+	assert.True(t, s.IsEmpty())
+	_, err := s.Pop()
+	assert.ErrorIs(t, err, ErrSetIsEmpty)
+
+	s.AddAll(1, 2, 3)
+	s.Clear()
+	assert.True(t, s.IsEmpty())
+}
+
+func TestConcurrentSet_Algebra(t *testing.T) {
+	a := NewConcurrentSet(1, 2, 3)
+	b := NewConcurrentSet(2, 3, 4)
+	type testCase struct {
+		name string
+		got  *ConcurrentSet[int]
+		want *Set[int]
+	}
+	tests := []testCase{
+		{name: "union", got: a.Union(b), want: NewSet(1, 2, 3, 4)},
+		{name: "intersect", got: a.Intersect(b), want: NewSet(2, 3)},
+		{name: "difference", got: a.Difference(b), want: NewSet(1)},
+		{name: "symmetric difference", got: a.SymmetricDifference(b), want: NewSet(1, 4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got.Snapshot())
+		})
+	}
+	assert.True(t, a.Intersect(b).IsSubset(a))
+	assert.False(t, a.Equal(b))
+	assert.True(t, a.Equal(NewConcurrentSet(3, 2, 1)))
+}
+
+func TestConcurrentSet_OperationsWhileMutating(t *testing.T) {
+	a := NewConcurrentSet[int]()
+	b := NewConcurrentSet[int]()
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				a.Add(i)
+				b.Add(i + w)
+				// operands in both orders must not deadlock
+				if w%2 == 0 {
+					a.Union(b)
+				} else {
+					b.Intersect(a)
+				}
+				if i%10 == 0 {
+					a.Discard(i)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for value := range a.All() {
+		a.Discard(value)
+	}
+	assert.True(t, a.IsEmpty(), "All must iterate over a snapshot")
+	assert.Equal(t, iterations+workers-1, b.Size())
+}
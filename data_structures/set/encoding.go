@@ -0,0 +1,123 @@
+package set
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Sorted returns the values of a set of ordered elements in ascending order
+func Sorted[T cmp.Ordered](s *Set[T]) []T {
+	return slices.Sorted(maps.Keys(*s))
+}
+
+// sortByKey orders values by keys taken once per value
+func sortByKey[T any, K cmp.Ordered](values []T, key func(i int) K) {
+	type keyed struct {
+		key   K
+		value T
+	}
+	pairs := make([]keyed, len(values))
+	for i, value := range values {
+		pairs[i] = keyed{key: key(i), value: value}
+	}
+	slices.SortFunc(pairs, func(a, b keyed) int { return cmp.Compare(a.key, b.key) })
+	for i, p := range pairs {
+		values[i] = p.value
+	}
+}
+
+// sortValues sorts values of numeric and string kinds, named types included,
+// each value is read through reflection once, not on every comparison,
+// it reports false and leaves values as they are for other kinds
+func sortValues[T comparable](values []T) bool {
+	v := reflect.ValueOf(values)
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sortByKey(values, func(i int) int64 { return v.Index(i).Int() })
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sortByKey(values, func(i int) uint64 { return v.Index(i).Uint() })
+	case reflect.Float32, reflect.Float64:
+		sortByKey(values, func(i int) float64 { return v.Index(i).Float() })
+	case reflect.String:
+		sortByKey(values, func(i int) string { return v.Index(i).String() })
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *Set[T]) values() []T {
+	values := make([]T, 0, s.Size())
+	for value := range *s {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Slice returns numbers and strings in ascending order, other values in no particular order,
+// use SortedSlice for them
+func (s *Set[T]) Slice() []T {
+	values := s.values()
+	sortValues(values)
+	return values
+}
+
+func (s *Set[T]) SortedSlice(less func(a, b T) bool) []T {
+	values := s.values()
+	sort.Slice(values, func(i, j int) bool {
+		return less(values[i], values[j])
+	})
+	return values
+}
+
+// String lists values in the order of Slice, values without a natural order are sorted by their text
+func (s *Set[T]) String() string {
+	values := s.values()
+	ordered := sortValues(values)
+	texts := make([]string, len(values))
+	for i, value := range values {
+		texts[i] = fmt.Sprint(value)
+	}
+	if !ordered {
+		slices.Sort(texts)
+	}
+	return "{" + strings.Join(texts, ", ") + "}"
+}
+
+// MarshalJSON encodes the values as a sorted array, values without a natural order are sorted by their encoding.
+// It has a value receiver, so sets stored by value in structs are encoded as arrays too
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	values := s.values()
+	if sortValues(values) {
+		return json.Marshal(values)
+	}
+	encoded := make([]json.RawMessage, len(values))
+	for i, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = data
+	}
+	slices.SortFunc(encoded, func(a, b json.RawMessage) int { return bytes.Compare(a, b) })
+	return json.Marshal(encoded)
+}
+
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	*s = make(Set[T], len(values))
+	for _, value := range values {
+		s.Add(value)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package set
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+type level int
+
+const (
+	low level = iota - 1
+	high
+)
+
+func TestSet_Slice(t *testing.T) {
+	assert.Equal(t, []int{-3, 1, 2, 10}, NewSet(10, 2, -3, 1).Slice())
+	assert.Equal(t, []uint8{1, 2, 200}, NewSet[uint8](200, 2, 1).Slice())
+	assert.Equal(t, []float64{-1.5, 0, 2.25}, NewSet(2.25, 0, -1.5).Slice())
+	assert.Equal(t, []string{"a", "b", "c"}, NewSet("c", "a", "b").Slice())
+	assert.Equal(t, []level{low, high}, NewSet(high, low).Slice())
+	assert.ElementsMatch(t, []point{{1, 2}, {2, 1}}, NewSet(point{2, 1}, point{1, 2}).Slice())
+	assert.ElementsMatch(t, []any{1, "a"}, NewSet[any]("a", 1).Slice())
+	assert.Empty(t, NewSet[int]().Slice())
+}
+
+func TestSorted(t *testing.T) {
+	assert.Equal(t, []int{-3, 1, 2, 10}, Sorted(NewSet(10, 2, -3, 1)))
+	assert.Equal(t, []level{low, high}, Sorted(NewSet(high, low)))
+	assert.Empty(t, Sorted(NewSet[string]()))
+}
+
+func TestSet_SortedSlice(t *testing.T) {
+	s := NewSet(point{1, 5}, point{2, 1}, point{3, 3})
+	got := s.SortedSlice(func(a, b point) bool { return a.Y < b.Y })
+	assert.Equal(t, []point{{2, 1}, {3, 3}, {1, 5}}, got)
+}
+
+func TestSet_String(t *testing.T) {
+	type testCase struct {
+		name string
+		s    fmt.Stringer
+		want string
+	}
+	tests := []testCase{
+		{name: "empty", s: NewSet[int](), want: "{}"},
+		{name: "ints", s: NewSet(3, 1, 2), want: "{1, 2, 3}"},
+		{name: "strings", s: NewSet("b", "a"), want: "{a, b}"},
+		{name: "by text", s: NewSet(point{2, 1}, point{1, 2}, point{10, 0}), want: "{{1 2}, {10 0}, {2 1}}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.s.String())
+			assert.Equal(t, tt.want, fmt.Sprint(tt.s))
+		})
+	}
+}
+
+func TestSet_JSON(t *testing.T) {
+	type config struct {
+		Tags  Set[string]  `json:"tags"`
+		Ports *Set[int]    `json:"ports"`
+		Empty *Set[string] `json:"empty,omitempty"`
+	}
+	c := config{Tags: *NewSet("web", "api"), Ports: NewSet(443, 80)}
+	data, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"tags":["api","web"],"ports":[80,443]}`, string(data))
+
+	var got config
+	assert.NoError(t, json.Unmarshal([]byte(`{"tags":["a","b","a"],"ports":[1]}`), &got))
+	assert.Equal(t, NewSet("a", "b"), &got.Tags)
+	assert.Equal(t, NewSet(1), got.Ports)
+
+	unordered := map[string]json.Marshaler{
+		`[false,true]`:                  NewSet(true, false),
+		`[{"X":1,"Y":2},{"X":2,"Y":1}]`: NewSet(point{2, 1}, point{1, 2}),
+		`["a",1]`:                       NewSet[any]("a", 1),
+	}
+	for want, s := range unordered {
+		for i := 0; i < 5; i++ {
+			data, err = json.Marshal(s)
+			assert.NoError(t, err)
+			assert.Equal(t, want, string(data))
+		}
+	}
+	var points Set[point]
+	assert.NoError(t, json.Unmarshal([]byte(`[{"X":1,"Y":2},{"X":2,"Y":1}]`), &points))
+	assert.Equal(t, NewSet(point{2, 1}, point{1, 2}), &points)
+
+	var s Set[int]
+	assert.NoError(t, json.Unmarshal([]byte(`null`), &s))
+	assert.True(t, s.IsEmpty())
+	assert.Error(t, json.Unmarshal([]byte(`{"a":1}`), &s))
+	assert.Error(t, json.Unmarshal([]byte(`["a"]`), &s))
+}
@@ -0,0 +1,218 @@
+package set
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// MultiSet is a bag which counts how many times every value was added
+type MultiSet[T comparable] struct {
+	counts map[T]int
+	// added numbers values in the order they were first added, it orders values without a natural order
+	added map[T]uint64
+	next  uint64
+	total int
+}
+
+// Element is a value of a MultiSet with its count
+type Element[T comparable] struct {
+	Value T
+	Count int
+}
+
+var ErrInvalidCount = errors.New("count must be positive")
+
+// Add adds n occurrences of value
+func (m *MultiSet[T]) Add(value T, n int) error {
+	if n < 1 {
+		return ErrInvalidCount
+	}
+	m.set(value, m.counts[value]+n)
+	return nil
+}
+
+// Remove removes n occurrences of value, or all of them if there are fewer
+func (m *MultiSet[T]) Remove(value T, n int) error {
+	if n < 1 {
+		return ErrInvalidCount
+	}
+	count, ok := m.counts[value]
+	if !ok {
+		return ErrValueIsNotFound
+	}
+	m.set(value, count-min(n, count))
+	return nil
+}
+
+// Discard removes every occurrence of value and returns how many there were
+func (m *MultiSet[T]) Discard(value T) int {
+	count := m.counts[value]
+	m.set(value, 0)
+	return count
+}
+
+func (m *MultiSet[T]) set(value T, count int) {
+	m.total += count - m.counts[value]
+	if count == 0 {
+		delete(m.counts, value)
+		delete(m.added, value)
+		return
+	}
+	if _, ok := m.added[value]; !ok {
+		m.added[value] = m.next
+		m.next++
+	}
+	m.counts[value] = count
+}
+
+func (m *MultiSet[T]) Count(value T) int {
+	return m.counts[value]
+}
+
+func (m *MultiSet[T]) Contains(value T) bool {
+	_, ok := m.counts[value]
+	return ok
+}
+
+// Size returns the number of occurrences of all values
+func (m *MultiSet[T]) Size() int {
+	return m.total
+}
+
+// Distinct returns the number of different values
+func (m *MultiSet[T]) Distinct() int {
+	return len(m.counts)
+}
+
+func (m *MultiSet[T]) IsEmpty() bool {
+	return m.total == 0
+}
+
+func (m *MultiSet[T]) RemoveAll() {
+	m.counts = make(map[T]int)
+	m.added = make(map[T]uint64)
+	m.next = 0
+	m.total = 0
+}
+
+// All iterates over the distinct values with their counts in no particular order
+func (m *MultiSet[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for value, count := range m.counts {
+			if !yield(value, count) {
+				return
+			}
+		}
+	}
+}
+
+// distinct returns numbers and strings in ascending order, other values in the order they were first added
+func (m *MultiSet[T]) distinct() []T {
+	values := make([]T, 0, len(m.counts))
+	for value := range m.counts {
+		values = append(values, value)
+	}
+	if !sortValues(values) {
+		slices.SortFunc(values, func(a, b T) int { return cmp.Compare(m.added[a], m.added[b]) })
+	}
+	return values
+}
+
+// Slice returns every occurrence, numbers and strings in ascending order,
+// other values in the order they were first added
+func (m *MultiSet[T]) Slice() []T {
+	values := make([]T, 0, m.total)
+	for _, value := range m.distinct() {
+		for i := 0; i < m.counts[value]; i++ {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func (m *MultiSet[T]) ToSet() *Set[T] {
+	s := make(Set[T], len(m.counts))
+	for value := range m.counts {
+		s[value] = struct{}{}
+	}
+	return &s
+}
+
+// MostCommon returns up to k values with the highest counts,
+// equal counts are ordered like Slice so the result is stable
+func (m *MultiSet[T]) MostCommon(k int) []Element[T] {
+	values := m.distinct()
+	elements := make([]Element[T], len(values))
+	for i, value := range values {
+		elements[i] = Element[T]{Value: value, Count: m.counts[value]}
+	}
+	slices.SortStableFunc(elements, func(a, b Element[T]) int { return cmp.Compare(b.Count, a.Count) })
+	return elements[:max(min(k, len(elements)), 0)]
+}
+
+// combine makes a multiset with op applied to the counts of every value of both multisets
+func (m *MultiSet[T]) combine(other *MultiSet[T], op func(a, b int) int) *MultiSet[T] {
+	result := NewMultiSet[T]()
+	for _, value := range m.distinct() {
+		result.set(value, max(op(m.counts[value], other.counts[value]), 0))
+	}
+	for _, value := range other.distinct() {
+		if _, ok := m.counts[value]; !ok {
+			result.set(value, max(op(0, other.counts[value]), 0))
+		}
+	}
+	return result
+}
+
+// Union keeps the larger count of every value
+func (m *MultiSet[T]) Union(other *MultiSet[T]) *MultiSet[T] {
+	return m.combine(other, func(a, b int) int { return max(a, b) })
+}
+
+// Sum adds the counts of both multisets
+func (m *MultiSet[T]) Sum(other *MultiSet[T]) *MultiSet[T] {
+	return m.combine(other, func(a, b int) int { return a + b })
+}
+
+// Intersect keeps the smaller count of every value
+func (m *MultiSet[T]) Intersect(other *MultiSet[T]) *MultiSet[T] {
+	return m.combine(other, func(a, b int) int { return min(a, b) })
+}
+
+// Difference subtracts the counts of other, values which drop to zero are removed
+func (m *MultiSet[T]) Difference(other *MultiSet[T]) *MultiSet[T] {
+	return m.combine(other, func(a, b int) int { return a - b })
+}
+
+// IsSubset reports whether every value of m occurs in other at least as many times
+func (m *MultiSet[T]) IsSubset(other *MultiSet[T]) bool {
+	if m.total > other.total {
+		return false
+	}
+	for value, count := range m.counts {
+		if count > other.counts[value] {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *MultiSet[T]) Equal(other *MultiSet[T]) bool {
+	return m.total == other.total && len(m.counts) == len(other.counts) && m.IsSubset(other)
+}
+
+func (m *MultiSet[T]) Clone() *MultiSet[T] {
+	return &MultiSet[T]{counts: maps.Clone(m.counts), added: maps.Clone(m.added), next: m.next, total: m.total}
+}
+
+// NewMultiSet counts every occurrence of values
+func NewMultiSet[T comparable](values ...T) *MultiSet[T] {
+	m := &MultiSet[T]{counts: make(map[T]int), added: make(map[T]uint64)}
+	for _, value := range values {
+		m.set(value, m.counts[value]+1)
+	}
+	return m
+}
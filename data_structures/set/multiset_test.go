@@ -0,0 +1,122 @@
+package set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMultiSet_AddRemove(t *testing.T) {
+	m := NewMultiSet("a", "b", "a")
+	assert.Equal(t, 3, m.Size())
+	assert.Equal(t, 2, m.Distinct())
+	assert.NoError(t, m.Add("c", 3))
+	assert.ErrorIs(t, m.Add("c", 0), ErrInvalidCount)
+	assert.Equal(t, 3, m.Count("c"))
+	assert.Equal(t, 0, m.Count("x"))
+	assert.Equal(t, 6, m.Size())
+
+	tests := []struct {
+		name      string
+		value     string
+		n         int
+		wantErr   error
+		wantCount int
+		wantSize  int
+	}{
+		{name: "some occurrences", value: "c", n: 2, wantCount: 1, wantSize: 4},
+		{name: "more than present", value: "a", n: 5, wantCount: 0, wantSize: 2},
+		{name: "missing value", value: "x", n: 1, wantErr: ErrValueIsNotFound, wantSize: 2},
+		{name: "invalid count", value: "b", n: -1, wantErr: ErrInvalidCount, wantCount: 1, wantSize: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, m.Remove(tt.value, tt.n), tt.wantErr)
+			assert.Equal(t, tt.wantCount, m.Count(tt.value))
+			assert.Equal(t, tt.wantSize, m.Size())
+		})
+	}
+	assert.False(t, m.Contains("a"), "values without occurrences must be dropped")
+	assert.Equal(t, 2, m.Distinct())
+
+	assert.Equal(t, 1, m.Discard("b"))
+	assert.Equal(t, 0, m.Discard("b"))
+	m.RemoveAll()
+	assert.True(t, m.IsEmpty())
+	assert.Equal(t, 0, m.Distinct())
+}
+
+func TestMultiSet_MostCommon(t *testing.T) {
+	m := NewMultiSet(3, 1, 2, 2, 3, 3, 4, 4)
+	tests := []struct {
+		name string
+		k    int
+		want []Element[int]
+	}{
+		{name: "top", k: 1, want: []Element[int]{{Value: 3, Count: 3}}},
+		{name: "ties by value", k: 3, want: []Element[int]{{3, 3}, {2, 2}, {4, 2}}},
+		{name: "more than distinct", k: 10, want: []Element[int]{{3, 3}, {2, 2}, {4, 2}, {1, 1}}},
+		{name: "negative", k: -1, want: []Element[int]{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, m.MostCommon(tt.k))
+		})
+	}
+}
+
+func TestMultiSet_UnorderedValues(t *testing.T) {
+	a, b, c := point{3, 0}, point{1, 0}, point{2, 0}
+	m := NewMultiSet(a, b, c, b, c, a)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, []Element[point]{{a, 2}, {b, 2}, {c, 2}}, m.MostCommon(3), "ties keep the order of first addition")
+		assert.Equal(t, []point{a, a, b, b, c, c}, m.Slice())
+	}
+	m.Discard(a)
+	assert.NoError(t, m.Add(a, 1))
+	assert.Equal(t, []point{b, b, c, c, a}, m.Slice(), "a value added again goes last")
+	assert.Equal(t, m.Slice(), m.Union(NewMultiSet(c)).Slice())
+	assert.Equal(t, m.Slice(), m.Clone().Slice())
+}
+
+func TestMultiSet_Algebra(t *testing.T) {
+	a := NewMultiSet(1, 1, 1, 2, 3, 3)
+	b := NewMultiSet(1, 2, 2, 4)
+	tests := []struct {
+		name string
+		got  *MultiSet[int]
+		want []int
+	}{
+		{name: "union", got: a.Union(b), want: []int{1, 1, 1, 2, 2, 3, 3, 4}},
+		{name: "sum", got: a.Sum(b), want: []int{1, 1, 1, 1, 2, 2, 2, 3, 3, 4}},
+		{name: "intersect", got: a.Intersect(b), want: []int{1, 2}},
+		{name: "difference", got: a.Difference(b), want: []int{1, 1, 3, 3}},
+		{name: "reverse difference", got: b.Difference(a), want: []int{2, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got.Slice())
+			assert.Equal(t, len(tt.want), tt.got.Size())
+			for value, count := range tt.got.All() {
+				assert.Positive(t, count, "value %v", value)
+			}
+		})
+	}
+	assert.Equal(t, []int{1, 1, 1, 2, 3, 3}, a.Slice(), "operands must not change")
+}
+
+func TestMultiSet_Predicates(t *testing.T) {
+	a := NewMultiSet("x", "y", "y")
+	b := a.Clone()
+	assert.True(t, a.Equal(b))
+	assert.True(t, a.IsSubset(b))
+
+	assert.NoError(t, b.Add("y", 1))
+	assert.False(t, a.Equal(b))
+	assert.True(t, a.IsSubset(b))
+	assert.False(t, b.IsSubset(a))
+	assert.Equal(t, 2, a.Count("y"), "clone must be independent")
+
+	// same total, different counts
+	assert.False(t, NewMultiSet("x", "x").Equal(NewMultiSet("x", "y")))
+	assert.True(t, NewSet("x", "y").Equal(b.ToSet()))
+}
@@ -0,0 +1,397 @@
+package set
+
+import (
+	"cmp"
+	"errors"
+	"iter"
+)
+
+type orderedNode[T cmp.Ordered] struct {
+	value  T
+	left   *orderedNode[T]
+	right  *orderedNode[T]
+	height int
+	// size of the subtree, used by Rank and Select
+	size int
+}
+
+func (n *orderedNode[T]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *orderedNode[T]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *orderedNode[T]) update() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+	n.size = 1 + n.left.getSize() + n.right.getSize()
+}
+
+func (n *orderedNode[T]) rotateLeft() *orderedNode[T] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	n.update()
+	r.update()
+	return r
+}
+
+func (n *orderedNode[T]) rotateRight() *orderedNode[T] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	n.update()
+	l.update()
+	return l
+}
+
+// balance restores the AVL invariant, subtree heights differ at most by one
+func (n *orderedNode[T]) balance() *orderedNode[T] {
+	n.update()
+	switch diff := n.left.getHeight() - n.right.getHeight(); {
+	case diff > 1:
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case diff < -1:
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	}
+	return n
+}
+
+func insertOrdered[T cmp.Ordered](n *orderedNode[T], value T) (*orderedNode[T], bool) {
+	if n == nil {
+		return &orderedNode[T]{value: value, height: 1, size: 1}, true
+	}
+	var added bool
+	switch c := cmp.Compare(value, n.value); {
+	case c < 0:
+		n.left, added = insertOrdered(n.left, value)
+	case c > 0:
+		n.right, added = insertOrdered(n.right, value)
+	default:
+		return n, false
+	}
+	return n.balance(), added
+}
+
+func removeOrdered[T cmp.Ordered](n *orderedNode[T], value T) (*orderedNode[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var removed bool
+	switch c := cmp.Compare(value, n.value); {
+	case c < 0:
+		n.left, removed = removeOrdered(n.left, value)
+	case c > 0:
+		n.right, removed = removeOrdered(n.right, value)
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		var successor *orderedNode[T]
+		n.right, successor = removeMinOrdered(n.right)
+		successor.left, successor.right = n.left, n.right
+		return successor.balance(), true
+	}
+	return n.balance(), removed
+}
+
+func removeMinOrdered[T cmp.Ordered](n *orderedNode[T]) (*orderedNode[T], *orderedNode[T]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var minNode *orderedNode[T]
+	n.left, minNode = removeMinOrdered(n.left)
+	return n.balance(), minNode
+}
+
+// buildOrdered makes a balanced tree of sorted distinct values in O(n)
+func buildOrdered[T cmp.Ordered](values []T) *orderedNode[T] {
+	if len(values) == 0 {
+		return nil
+	}
+	mid := len(values) / 2
+	n := &orderedNode[T]{value: values[mid], left: buildOrdered(values[:mid]), right: buildOrdered(values[mid+1:])}
+	n.update()
+	return n
+}
+
+// OrderedSet keeps values sorted in an AVL tree,
+// lookups, updates, Rank and Select take O(log n)
+type OrderedSet[T cmp.Ordered] struct {
+	root *orderedNode[T]
+}
+
+func (s *OrderedSet[T]) Add(value T) bool {
+	var added bool
+	s.root, added = insertOrdered(s.root, value)
+	return added
+}
+
+func (s *OrderedSet[T]) Remove(value T) error {
+	if !s.Discard(value) {
+		return ErrValueIsNotFound
+	}
+	return nil
+}
+
+func (s *OrderedSet[T]) Discard(value T) bool {
+	var removed bool
+	s.root, removed = removeOrdered(s.root, value)
+	return removed
+}
+
+func (s *OrderedSet[T]) IsEmpty() bool {
+	return s.root == nil
+}
+
+func (s *OrderedSet[T]) Size() int {
+	return s.root.getSize()
+}
+
+func (s *OrderedSet[T]) RemoveAll() {
+	s.root = nil
+}
+
+func (s *OrderedSet[T]) Contains(value T) bool {
+	for n := s.root; n != nil; {
+		switch c := cmp.Compare(value, n.value); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+func (s *OrderedSet[T]) Min() (T, error) {
+	if s.root == nil {
+		return *new(T), ErrSetIsEmpty
+	}
+	n := s.root
+	for n.left != nil {
+		n = n.left
+	}
+	return n.value, nil
+}
+
+func (s *OrderedSet[T]) Max() (T, error) {
+	if s.root == nil {
+		return *new(T), ErrSetIsEmpty
+	}
+	n := s.root
+	for n.right != nil {
+		n = n.right
+	}
+	return n.value, nil
+}
+
+// Floor returns the greatest value less than or equal to value
+func (s *OrderedSet[T]) Floor(value T) (T, bool) {
+	var floor *orderedNode[T]
+	for n := s.root; n != nil; {
+		switch c := cmp.Compare(value, n.value); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			floor = n
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	if floor == nil {
+		return *new(T), false
+	}
+	return floor.value, true
+}
+
+// Ceiling returns the least value greater than or equal to value
+func (s *OrderedSet[T]) Ceiling(value T) (T, bool) {
+	var ceiling *orderedNode[T]
+	for n := s.root; n != nil; {
+		switch c := cmp.Compare(value, n.value); {
+		case c < 0:
+			ceiling = n
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	if ceiling == nil {
+		return *new(T), false
+	}
+	return ceiling.value, true
+}
+
+// Rank returns the number of values less than value
+func (s *OrderedSet[T]) Rank(value T) int {
+	var rank int
+	for n := s.root; n != nil; {
+		switch c := cmp.Compare(value, n.value); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			rank += n.left.getSize() + 1
+			n = n.right
+		default:
+			return rank + n.left.getSize()
+		}
+	}
+	return rank
+}
+
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+// Select returns the value with the given rank, starting from 0
+func (s *OrderedSet[T]) Select(i int) (T, error) {
+	if i < 0 || i >= s.Size() {
+		return *new(T), ErrIndexOutOfRange
+	}
+	n := s.root
+	for {
+		switch left := n.left.getSize(); {
+		case i < left:
+			n = n.left
+		case i > left:
+			i -= left + 1
+			n = n.right
+		default:
+			return n.value, nil
+		}
+	}
+}
+
+// ascend calls yield for values in [lo, hi] in order, a nil bound is open
+func (n *orderedNode[T]) ascend(lo, hi *T, yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+	if lo == nil || cmp.Less(*lo, n.value) {
+		if !n.left.ascend(lo, hi, yield) {
+			return false
+		}
+	}
+	if (lo == nil || cmp.Compare(*lo, n.value) <= 0) && (hi == nil || cmp.Compare(n.value, *hi) <= 0) {
+		if !yield(n.value) {
+			return false
+		}
+	}
+	if hi == nil || cmp.Less(n.value, *hi) {
+		return n.right.ascend(lo, hi, yield)
+	}
+	return true
+}
+
+// Range returns the values in [lo, hi] in ascending order
+func (s *OrderedSet[T]) Range(lo, hi T) []T {
+	var values []T
+	s.root.ascend(&lo, &hi, func(value T) bool {
+		values = append(values, value)
+		return true
+	})
+	return values
+}
+
+// All iterates over the values in ascending order
+func (s *OrderedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.root.ascend(nil, nil, yield)
+	}
+}
+
+func (s *OrderedSet[T]) Values() []T {
+	values := make([]T, 0, s.Size())
+	for value := range s.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// merge walks both sorted sets at once and keeps values accepted by keep,
+// inLeft and inRight tell which set holds the value
+func (s *OrderedSet[T]) merge(other *OrderedSet[T], keep func(inLeft, inRight bool) bool) *OrderedSet[T] {
+	left, right := s.Values(), other.Values()
+	values := make([]T, 0, max(len(left), len(right)))
+	i, j := 0, 0
+	for i < len(left) || j < len(right) {
+		var value T
+		var inLeft, inRight bool
+		switch {
+		case j == len(right) || (i < len(left) && cmp.Less(left[i], right[j])):
+			value, inLeft = left[i], true
+			i++
+		case i == len(left) || cmp.Less(right[j], left[i]):
+			value, inRight = right[j], true
+			j++
+		default:
+			value, inLeft, inRight = left[i], true, true
+			i++
+			j++
+		}
+		if keep(inLeft, inRight) {
+			values = append(values, value)
+		}
+	}
+	return &OrderedSet[T]{root: buildOrdered(values)}
+}
+
+func (s *OrderedSet[T]) Union(other *OrderedSet[T]) *OrderedSet[T] {
+	return s.merge(other, func(inLeft, inRight bool) bool { return true })
+}
+
+func (s *OrderedSet[T]) Intersect(other *OrderedSet[T]) *OrderedSet[T] {
+	return s.merge(other, func(inLeft, inRight bool) bool { return inLeft && inRight })
+}
+
+func (s *OrderedSet[T]) Difference(other *OrderedSet[T]) *OrderedSet[T] {
+	return s.merge(other, func(inLeft, inRight bool) bool { return !inRight })
+}
+
+func (s *OrderedSet[T]) SymmetricDifference(other *OrderedSet[T]) *OrderedSet[T] {
+	return s.merge(other, func(inLeft, inRight bool) bool { return inLeft != inRight })
+}
+
+func (s *OrderedSet[T]) IsSubset(other *OrderedSet[T]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+	for value := range s.All() {
+		if !other.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *OrderedSet[T]) Equal(other *OrderedSet[T]) bool {
+	return s.Size() == other.Size() && s.IsSubset(other)
+}
+
+func NewOrderedSet[T cmp.Ordered](values ...T) *OrderedSet[T] {
+	s := &OrderedSet[T]{}
+	for _, value := range values {
+		s.Add(value)
+	}
+	return s
+}
@@ -0,0 +1,175 @@
+package set
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// assertAVL checks heights, sizes and ordering of every subtree
+func assertAVL[T int | string](t *testing.T, n *orderedNode[T], lo, hi *T) {
+	t.Helper()
+	if n == nil {
+		return
+	}
+	if lo != nil {
+		assert.Less(t, *lo, n.value)
+	}
+	if hi != nil {
+		assert.Less(t, n.value, *hi)
+	}
+	assert.LessOrEqual(t, abs(n.left.getHeight()-n.right.getHeight()), 1)
+	assert.Equal(t, 1+max(n.left.getHeight(), n.right.getHeight()), n.height)
+	assert.Equal(t, 1+n.left.getSize()+n.right.getSize(), n.size)
+	assertAVL(t, n.left, lo, &n.value)
+	assertAVL(t, n.right, &n.value, hi)
+}
+
+func abs(v int) int {
+	return max(v, -v)
+}
+
+func TestOrderedSet_Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	s := NewOrderedSet[int]()
+	want := NewSet[int]()
+	for i := 0; i < 5000; i++ {
+		value := rnd.Intn(1000)
+		if rnd.Intn(3) == 0 {
+			assert.Equal(t, want.Discard(value), s.Discard(value))
+			continue
+		}
+		assert.Equal(t, want.Add(value), s.Add(value))
+	}
+	assertAVL(t, s.root, nil, nil)
+	assert.Equal(t, want.Size(), s.Size())
+
+	sorted := make([]int, 0, want.Size())
+	for value := range *want {
+		sorted = append(sorted, value)
+	}
+	slices.Sort(sorted)
+	assert.Equal(t, sorted, s.Values())
+	for i, value := range sorted {
+		assert.Equal(t, i, s.Rank(value))
+		got, err := s.Select(i)
+		assert.NoError(t, err)
+		assert.Equal(t, value, got)
+	}
+}
+
+func TestOrderedSet_Remove(t *testing.T) {
+	s := NewOrderedSet(3, 1, 2)
+	assert.NoError(t, s.Remove(2))
+	assert.ErrorIs(t, s.Remove(2), ErrValueIsNotFound)
+	assert.False(t, s.Contains(2))
+	assert.True(t, s.Contains(1))
+	assert.Equal(t, []int{1, 3}, s.Values())
+
+	s.RemoveAll()
+	assert.True(t, s.IsEmpty())
+	_, err := s.Min()
+	assert.ErrorIs(t, err, ErrSetIsEmpty)
+	_, err = s.Max()
+	assert.ErrorIs(t, err, ErrSetIsEmpty)
+}
+
+func TestOrderedSet_Bounds(t *testing.T) {
+	s := NewOrderedSet(10, 20, 30, 40)
+	minValue, _ := s.Min()
+	maxValue, _ := s.Max()
+	assert.Equal(t, 10, minValue)
+	assert.Equal(t, 40, maxValue)
+
+	type testCase struct {
+		name        string
+		arg         int
+		wantFloor   int
+		wantFloorOk bool
+		wantCeil    int
+		wantCeilOk  bool
+		wantRank    int
+	}
+	tests := []testCase{
+		{name: "below min", arg: 5, wantCeil: 10, wantCeilOk: true},
+		{name: "exact", arg: 20, wantFloor: 20, wantFloorOk: true, wantCeil: 20, wantCeilOk: true, wantRank: 1},
+		{name: "between", arg: 25, wantFloor: 20, wantFloorOk: true, wantCeil: 30, wantCeilOk: true, wantRank: 2},
+		{name: "above max", arg: 50, wantFloor: 40, wantFloorOk: true, wantRank: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := s.Floor(tt.arg)
+			assert.Equal(t, tt.wantFloorOk, ok)
+			assert.Equal(t, tt.wantFloor, got)
+			got, ok = s.Ceiling(tt.arg)
+			assert.Equal(t, tt.wantCeilOk, ok)
+			assert.Equal(t, tt.wantCeil, got)
+			assert.Equal(t, tt.wantRank, s.Rank(tt.arg))
+		})
+	}
+
+	_, err := s.Select(4)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+	_, err = s.Select(-1)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+}
+
+func TestOrderedSet_Range(t *testing.T) {
+	s := NewOrderedSet("b", "d", "a", "e", "c")
+	type testCase struct {
+		name   string
+		lo, hi string
+		want   []string
+	}
+	tests := []testCase{
+		{name: "inclusive", lo: "b", hi: "d", want: []string{"b", "c", "d"}},
+		{name: "between values", lo: "bb", hi: "dd", want: []string{"c", "d"}},
+		{name: "all", lo: "", hi: "z", want: []string{"a", "b", "c", "d", "e"}},
+		{name: "empty", lo: "x", hi: "z"},
+		{name: "reversed bounds", lo: "d", hi: "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, s.Range(tt.lo, tt.hi))
+		})
+	}
+
+	var first []string
+	for value := range s.All() {
+		first = append(first, value)
+		if len(first) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []string{"a", "b"}, first)
+}
+
+func TestOrderedSet_Algebra(t *testing.T) {
+	a := NewOrderedSet(1, 2, 3, 4)
+	b := NewOrderedSet(3, 4, 5)
+	type testCase struct {
+		name string
+		got  *OrderedSet[int]
+		want []int
+	}
+	tests := []testCase{
+		{name: "union", got: a.Union(b), want: []int{1, 2, 3, 4, 5}},
+		{name: "intersect", got: a.Intersect(b), want: []int{3, 4}},
+		{name: "difference", got: a.Difference(b), want: []int{1, 2}},
+		{name: "symmetric difference", got: a.SymmetricDifference(b), want: []int{1, 2, 5}},
+		{name: "intersect with empty", got: a.Intersect(NewOrderedSet[int]()), want: []int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.got.Values())
+			assertAVL(t, tt.got.root, nil, nil)
+		})
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, a.Values(), "operations must not change the source")
+
+	assert.True(t, a.Intersect(b).IsSubset(b))
+	assert.False(t, a.IsSubset(b))
+	assert.True(t, a.Equal(NewOrderedSet(4, 3, 2, 1)))
+	assert.False(t, a.Equal(b))
+}
@@ -1,5 +1,7 @@
 package set
 
+import "errors"
+
 type Set[T comparable] map[T]struct{}
 
 func (s *Set[T]) Add(value T) bool {
@@ -9,13 +11,40 @@ func (s *Set[T]) Add(value T) bool {
 }
 
 func (s *Set[T]) IsEmpty() bool {
-	return len(*s) < 0
+	return len(*s) == 0
 }
 
 func (s *Set[T]) Size() int {
 	return len(*s)
 }
 
+var ErrValueIsNotFound = errors.New("cannot find value")
+
+func (s *Set[T]) Remove(value T) error {
+	if !s.Discard(value) {
+		return ErrValueIsNotFound
+	}
+	return nil
+}
+
+// Discard reports whether value was in the set
+func (s *Set[T]) Discard(value T) bool {
+	oldLen := len(*s)
+	delete(*s, value)
+	return oldLen != len(*s)
+}
+
+var ErrSetIsEmpty = errors.New("set is empty")
+
+// Pop removes an arbitrary value
+func (s *Set[T]) Pop() (T, error) {
+	for value := range *s {
+		delete(*s, value)
+		return value, nil
+	}
+	return *new(T), ErrSetIsEmpty
+}
+
 func (s *Set[T]) RemoveAll() {
 	*s = Set[T]{}
 }
@@ -82,7 +111,7 @@ func (s *Set[T]) Union(other *Set[T]) *Set[T] {
 }
 
 func (s *Set[T]) IsSubset(other *Set[T]) bool {
-	if s.Size() < other.Size() {
+	if s.Size() > other.Size() {
 		return false
 	}
 	for value := range *s {
@@ -93,6 +122,56 @@ func (s *Set[T]) IsSubset(other *Set[T]) bool {
 	return true
 }
 
+func (s *Set[T]) IsSuperset(other *Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+func (s *Set[T]) IsDisjoint(other *Set[T]) bool {
+	small, big := s, other
+	if small.Size() > big.Size() {
+		small, big = big, small
+	}
+	for value := range *small {
+		if big.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return s.Size() == other.Size() && s.IsSubset(other)
+}
+
+func (s *Set[T]) Clone() *Set[T] {
+	cloneSet := make(Set[T], s.Size())
+	for value := range *s {
+		cloneSet[value] = struct{}{}
+	}
+	return &cloneSet
+}
+
+// UnionWith adds the values of other to s instead of allocating a new set
+func (s *Set[T]) UnionWith(other *Set[T]) {
+	for value := range *other {
+		s.Add(value)
+	}
+}
+
+func (s *Set[T]) IntersectWith(other *Set[T]) {
+	for value := range *s {
+		if !other.Contains(value) {
+			delete(*s, value)
+		}
+	}
+}
+
+func (s *Set[T]) DifferenceWith(other *Set[T]) {
+	for value := range *other {
+		delete(*s, value)
+	}
+}
+
 func NewSet[T comparable](values ...T) *Set[T] {
 	s := make(Set[T])
 	for _, value := range values {
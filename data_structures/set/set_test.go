@@ -312,6 +312,18 @@ func TestSet_IsSubset(t *testing.T) {
 			name: "subset when empty source",
 			s:    &Set[int]{},
 			args: &Set[int]{1: struct{}{}, 2: struct{}{}, 3: struct{}{}},
+			want: true,
+		},
+		{
+			name: "proper subset",
+			s:    &Set[int]{2: struct{}{}, 3: struct{}{}},
+			args: &Set[int]{1: struct{}{}, 2: struct{}{}, 3: struct{}{}},
+			want: true,
+		},
+		{
+			name: "no subset when source is bigger",
+			s:    &Set[int]{1: struct{}{}, 2: struct{}{}, 3: struct{}{}},
+			args: &Set[int]{2: struct{}{}, 3: struct{}{}},
 		},
 		{
 			name: "subset when empty diff",
@@ -336,3 +348,146 @@ func TestSet_IsSubset(t *testing.T) {
 		})
 	}
 }
+
+func TestSet_IsEmpty(t *testing.T) {
+	assert.True(t, NewSet[int]().IsEmpty())
+	assert.False(t, NewSet(1).IsEmpty())
+}
+
+func TestSet_Remove(t *testing.T) {
+	s := NewSet(1, 2, 3)
+	assert.NoError(t, s.Remove(2))
+	assert.ErrorIs(t, s.Remove(2), ErrValueIsNotFound)
+	assert.True(t, s.Discard(3))
+	assert.False(t, s.Discard(3))
+	assert.ElementsMatch(t, []int{1}, keys(s))
+}
+
+func TestSet_Pop(t *testing.T) {
+	s := NewSet(1, 2)
+	var popped []int
+	for !s.IsEmpty() {
+		v, err := s.Pop()
+		assert.NoError(t, err)
+		popped = append(popped, v)
+	}
+	assert.ElementsMatch(t, []int{1, 2}, popped)
+	_, err := s.Pop()
+	assert.ErrorIs(t, err, ErrSetIsEmpty)
+}
+
+func TestSet_Predicates(t *testing.T) {
+	type testCase[T comparable] struct {
+		name         string
+		s            *Set[T]
+		args         *Set[T]
+		wantSuperset bool
+		wantDisjoint bool
+		wantEqual    bool
+	}
+	tests := []testCase[int]{
+		{
+			name:         "both empty",
+			s:            &Set[int]{},
+			args:         &Set[int]{},
+			wantSuperset: true,
+			wantDisjoint: true,
+			wantEqual:    true,
+		},
+		{
+			name:         "superset",
+			s:            &Set[int]{1: struct{}{}, 2: struct{}{}, 3: struct{}{}},
+			args:         &Set[int]{2: struct{}{}, 3: struct{}{}},
+			wantSuperset: true,
+		},
+		{
+			name: "overlapping",
+			s:    &Set[int]{1: struct{}{}, 2: struct{}{}},
+			args: &Set[int]{2: struct{}{}, 3: struct{}{}},
+		},
+		{
+			name:         "disjoint",
+			s:            &Set[int]{1: struct{}{}},
+			args:         &Set[int]{2: struct{}{}, 3: struct{}{}},
+			wantDisjoint: true,
+		},
+		{
+			name:         "equal",
+			s:            &Set[int]{1: struct{}{}, 2: struct{}{}},
+			args:         &Set[int]{2: struct{}{}, 1: struct{}{}},
+			wantSuperset: true,
+			wantEqual:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.wantSuperset, tt.s.IsSuperset(tt.args), "IsSuperset(%v)", tt.args)
+			assert.Equalf(t, tt.wantDisjoint, tt.s.IsDisjoint(tt.args), "IsDisjoint(%v)", tt.args)
+			assert.Equalf(t, tt.wantDisjoint, tt.args.IsDisjoint(tt.s), "IsDisjoint(%v)", tt.s)
+			assert.Equalf(t, tt.wantEqual, tt.s.Equal(tt.args), "Equal(%v)", tt.args)
+		})
+	}
+}
+
+func TestSet_Clone(t *testing.T) {
+	s := NewSet(1, 2)
+	c := s.Clone()
+	assert.Equal(t, s, c)
+	c.Add(3)
+	assert.False(t, s.Contains(3))
+}
+
+func TestSet_InPlace(t *testing.T) {
+	type testCase[T comparable] struct {
+		name    string
+		f       func(s, other *Set[T])
+		s       *Set[T]
+		args    *Set[T]
+		want    *Set[T]
+		wantNew func(s, other *Set[T]) *Set[T]
+	}
+	tests := []testCase[int]{
+		{
+			name:    "union with",
+			f:       (*Set[int]).UnionWith,
+			wantNew: (*Set[int]).Union,
+			s:       &Set[int]{1: struct{}{}, 2: struct{}{}},
+			args:    &Set[int]{2: struct{}{}, 3: struct{}{}},
+			want:    &Set[int]{1: struct{}{}, 2: struct{}{}, 3: struct{}{}},
+		},
+		{
+			name:    "intersect with",
+			f:       (*Set[int]).IntersectWith,
+			wantNew: (*Set[int]).Intersect,
+			s:       &Set[int]{1: struct{}{}, 2: struct{}{}},
+			args:    &Set[int]{2: struct{}{}, 3: struct{}{}},
+			want:    &Set[int]{2: struct{}{}},
+		},
+		{
+			name:    "difference with",
+			f:       (*Set[int]).DifferenceWith,
+			wantNew: (*Set[int]).Difference,
+			s:       &Set[int]{1: struct{}{}, 2: struct{}{}},
+			args:    &Set[int]{2: struct{}{}, 3: struct{}{}},
+			want:    &Set[int]{1: struct{}{}},
+		},
+		{
+			name:    "difference with itself",
+			f:       (*Set[int]).DifferenceWith,
+			wantNew: (*Set[int]).Difference,
+			s:       &Set[int]{1: struct{}{}, 2: struct{}{}},
+			want:    &Set[int]{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			if args == nil {
+				args = tt.s
+			}
+			assert.Equal(t, tt.want, tt.wantNew(tt.s, args))
+			tt.f(tt.s, args)
+			assert.Equal(t, tt.want, tt.s)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package sketch
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// CountMin never underestimates a count, with probability 1-delta
+// the estimate exceeds it by at most epsilon*Total
+type CountMin[K comparable] struct {
+	hashing[K]
+	width    uint64
+	depth    uint64
+	total    uint64
+	counters []uint64
+}
+
+func (s *CountMin[K]) Width() uint64 {
+	return s.width
+}
+
+func (s *CountMin[K]) Depth() uint64 {
+	return s.depth
+}
+
+// Total returns the sum of all added counts
+func (s *CountMin[K]) Total() uint64 {
+	return s.total
+}
+
+// cell returns the counter index of the row, rows use double hashing of a single hash
+func (s *CountMin[K]) cell(h, row uint64) uint64 {
+	h1, h2 := h, h>>32|h<<32|1
+	return row*s.width + (h1+row*h2)%s.width
+}
+
+func (s *CountMin[K]) Add(key K, count uint64) error {
+	h, err := s.hash(key)
+	if err != nil {
+		return err
+	}
+	for row := uint64(0); row < s.depth; row++ {
+		s.counters[s.cell(h, row)] += count
+	}
+	s.total += count
+	return nil
+}
+
+func (s *CountMin[K]) Estimate(key K) (uint64, error) {
+	h, err := s.hash(key)
+	if err != nil {
+		return 0, err
+	}
+	est := uint64(math.MaxUint64)
+	for row := uint64(0); row < s.depth; row++ {
+		est = min(est, s.counters[s.cell(h, row)])
+	}
+	return est, nil
+}
+
+func (s *CountMin[K]) Merge(other *CountMin[K]) error {
+	if s.width != other.width || s.depth != other.depth {
+		return ErrIncompatibleSketches
+	}
+	for i, c := range other.counters {
+		s.counters[i] += c
+	}
+	s.total += other.total
+	return nil
+}
+
+func (s *CountMin[K]) Clear() {
+	s.total = 0
+	clear(s.counters)
+}
+
+func (s *CountMin[K]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 1+8*(3+len(s.counters)))
+	data = append(data, kindCountMin)
+	for _, v := range []uint64{s.width, s.depth, s.total} {
+		data = binary.LittleEndian.AppendUint64(data, v)
+	}
+	for _, c := range s.counters {
+		data = binary.LittleEndian.AppendUint64(data, c)
+	}
+	return data, nil
+}
+
+func (s *CountMin[K]) UnmarshalBinary(data []byte) error {
+	if len(data) < 1 || data[0] != kindCountMin {
+		return ErrInvalidData
+	}
+	header, payload, err := readUint64(data[1:], 3)
+	if err != nil {
+		return err
+	}
+	width, depth := header[0], header[1]
+	hi, cells := bits.Mul64(width, depth)
+	if width < 1 || depth < 1 || hi != 0 || uint64(len(payload))%8 != 0 || uint64(len(payload))/8 != cells {
+		return ErrInvalidData
+	}
+	counters, _, err := readUint64(payload, int(cells))
+	if err != nil {
+		return err
+	}
+	s.width, s.depth, s.total, s.counters = width, depth, header[2], counters
+	return nil
+}
+
+var (
+	ErrInvalidErrorBound = errors.New("epsilon and delta must be in (0, 1)")
+	ErrInvalidSize       = errors.New("width and depth must be positive and width*depth must fit an int")
+)
+
+// NewCountMin sizes the sketch as width e/epsilon and depth ln(1/delta)
+func NewCountMin[K comparable](epsilon, delta float64, opts ...hash_table.Option[K]) (*CountMin[K], error) {
+	if epsilon <= 0 || epsilon >= 1 || delta <= 0 || delta >= 1 {
+		return nil, ErrInvalidErrorBound
+	}
+	// a tiny epsilon makes the width +Inf or too large for uint64, the conversion isn't defined then
+	w := math.Ceil(math.E / epsilon)
+	if !(w < math.MaxInt) {
+		return nil, ErrInvalidSize
+	}
+	width := uint64(w)
+	depth := uint64(math.Ceil(math.Log(1 / delta)))
+	return NewCountMinWithSize[K](width, depth, opts...)
+}
+
+func NewCountMinWithSize[K comparable](width, depth uint64, opts ...hash_table.Option[K]) (*CountMin[K], error) {
+	hi, cells := bits.Mul64(width, depth)
+	if width < 1 || depth < 1 || hi != 0 || cells > math.MaxInt {
+		return nil, ErrInvalidSize
+	}
+	return &CountMin[K]{
+		hashing:  newHashing(opts),
+		width:    width,
+		depth:    depth,
+		counters: make([]uint64, cells),
+	}, nil
+}
@@ -0,0 +1,145 @@
+package sketch
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"math/rand"
+	"testing"
+)
+
+func TestNewCountMin(t *testing.T) {
+	type testCase struct {
+		name      string
+		epsilon   float64
+		delta     float64
+		wantWidth uint64
+		wantDepth uint64
+		wantErr   error
+	}
+	tests := []testCase{
+		{name: "got error with zero epsilon", epsilon: 0, delta: 0.01, wantErr: ErrInvalidErrorBound},
+		{name: "got error with delta one", epsilon: 0.01, delta: 1, wantErr: ErrInvalidErrorBound},
+		{name: "got error with infinite width", epsilon: 1e-320, delta: 0.01, wantErr: ErrInvalidSize},
+		{name: "got error with width beyond int", epsilon: 1e-19, delta: 0.01, wantErr: ErrInvalidSize},
+		{name: "one percent", epsilon: 0.01, delta: 0.01, wantWidth: 272, wantDepth: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCountMin[int](tt.epsilon, tt.delta)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantWidth, got.Width())
+			assert.Equal(t, tt.wantDepth, got.Depth())
+		})
+	}
+
+	for _, size := range [][2]uint64{{0, 1}, {1, 0}, {1 << 32, 1 << 32}, {1 << 63, 1}} {
+		_, err := NewCountMinWithSize[int](size[0], size[1])
+		assert.ErrorIs(t, err, ErrInvalidSize, "width %v, depth %v", size[0], size[1])
+	}
+}
+
+func TestCountMin_Estimate(t *testing.T) {
+	s, _ := NewCountMin[string](0.001, 0.01)
+	rnd := rand.New(rand.NewSource(1))
+	want := make(map[string]uint64)
+	for i := 0; i < 100000; i++ {
+		// a skewed stream, small keys are much more frequent
+		key := fmt.Sprint(rnd.Intn(1 + rnd.Intn(1000)))
+		want[key]++
+		assert.NoError(t, s.Add(key, 1))
+	}
+	assert.Equal(t, uint64(100000), s.Total())
+	bound := uint64(0.001 * float64(s.Total()))
+	var exceeded int
+	for key, count := range want {
+		got, err := s.Estimate(key)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, got, count, "count-min never underestimates")
+		if got > count+bound {
+			exceeded++
+		}
+	}
+	assert.LessOrEqual(t, float64(exceeded)/float64(len(want)), 0.01)
+
+	got, _ := s.Estimate("absent")
+	assert.LessOrEqual(t, got, bound)
+	s.Clear()
+	got, _ = s.Estimate("0")
+	assert.Zero(t, got)
+	assert.Zero(t, s.Total())
+}
+
+func TestCountMin_Merge(t *testing.T) {
+	a, _ := NewCountMinWithSize[int](100, 4)
+	b, _ := NewCountMinWithSize[int](100, 4)
+	_ = a.Add(1, 5)
+	_ = b.Add(1, 7)
+	_ = b.Add(2, 3)
+	assert.NoError(t, a.Merge(b))
+	got, _ := a.Estimate(1)
+	assert.Equal(t, uint64(12), got)
+	got, _ = a.Estimate(2)
+	assert.Equal(t, uint64(3), got)
+	assert.Equal(t, uint64(15), a.Total())
+
+	c, _ := NewCountMinWithSize[int](100, 3)
+	assert.ErrorIs(t, a.Merge(c), ErrIncompatibleSketches)
+}
+
+func TestCountMin_MarshalBinary(t *testing.T) {
+	s, _ := NewCountMinWithSize[string](50, 3)
+	_ = s.Add("a", 10)
+	data, err := s.MarshalBinary()
+	assert.NoError(t, err)
+
+	var got CountMin[string]
+	assert.NoError(t, got.UnmarshalBinary(data))
+	assert.Equal(t, s.counters, got.counters)
+	assert.Equal(t, s.Total(), got.Total())
+	est, _ := got.Estimate("a")
+	assert.Equal(t, uint64(10), est)
+	assert.NoError(t, got.Merge(s))
+
+	tests := map[string][]byte{
+		"empty":          nil,
+		"other kind":     {kindHyperLogLog},
+		"short header":   data[:10],
+		"short payload":  data[:len(data)-1],
+		"wrapping size":  countMinHeader(1<<61, 1),
+		"wrapping cells": countMinHeader(1<<32, 1<<32),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got CountMin[string]
+			assert.ErrorIs(t, got.UnmarshalBinary(data), ErrInvalidData)
+		})
+	}
+}
+
+// countMinHeader encodes an empty sketch claiming width*depth counters
+func countMinHeader(width, depth uint64) []byte {
+	data := []byte{kindCountMin}
+	for _, v := range []uint64{width, depth, 0} {
+		data = binary.LittleEndian.AppendUint64(data, v)
+	}
+	return data
+}
+
+func TestCountMin_HasherError(t *testing.T) {
+	h := hash_table.MockHasher[int]{}
+	h.On("Hash", mock.AnythingOfType("int")).
+		Return(uint64(0), errors.New("test err"))
+	s, _ := NewCountMinWithSize[int](10, 2, hash_table.WithHasher[int](&h))
+
+	assert.Error(t, s.Add(1, 1))
+	_, err := s.Estimate(1)
+	assert.Error(t, err)
+	assert.Zero(t, s.Total())
+}
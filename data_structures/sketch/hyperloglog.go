@@ -0,0 +1,113 @@
+package sketch
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"errors"
+	"math"
+	"math/bits"
+)
+
+const (
+	MinPrecision uint8 = 4
+	MaxPrecision uint8 = 18
+)
+
+// HyperLogLog estimates distinct keys with the standard error 1.04/sqrt(2^precision)
+// using one byte per register
+type HyperLogLog[K comparable] struct {
+	hashing[K]
+	precision uint8
+	registers []uint8
+}
+
+func (s *HyperLogLog[K]) Precision() uint8 {
+	return s.precision
+}
+
+func (s *HyperLogLog[K]) Add(key K) error {
+	h, err := s.hash(key)
+	if err != nil {
+		return err
+	}
+	i := h >> (64 - s.precision)
+	// the guard bit bounds the rank when the remaining bits are zero
+	rank := uint8(bits.LeadingZeros64(h<<s.precision|1<<(s.precision-1))) + 1
+	s.registers[i] = max(s.registers[i], rank)
+	return nil
+}
+
+func (s *HyperLogLog[K]) Count() uint64 {
+	m := float64(len(s.registers))
+	var sum float64
+	var zeros int
+	for _, r := range s.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	est := alpha(len(s.registers)) * m * m / sum
+	// linear counting is more accurate for small cardinalities
+	if est <= 2.5*m && zeros > 0 {
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(est))
+}
+
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	}
+	return 0.7213 / (1 + 1.079/float64(m))
+}
+
+func (s *HyperLogLog[K]) Merge(other *HyperLogLog[K]) error {
+	if s.precision != other.precision {
+		return ErrIncompatibleSketches
+	}
+	for i, r := range other.registers {
+		s.registers[i] = max(s.registers[i], r)
+	}
+	return nil
+}
+
+func (s *HyperLogLog[K]) Clear() {
+	clear(s.registers)
+}
+
+func (s *HyperLogLog[K]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, 2+len(s.registers))
+	data = append(data, kindHyperLogLog, s.precision)
+	return append(data, s.registers...), nil
+}
+
+func (s *HyperLogLog[K]) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != kindHyperLogLog {
+		return ErrInvalidData
+	}
+	precision := data[1]
+	if precision < MinPrecision || precision > MaxPrecision || len(data)-2 != 1<<precision {
+		return ErrInvalidData
+	}
+	s.precision = precision
+	s.registers = append([]uint8(nil), data[2:]...)
+	return nil
+}
+
+var ErrInvalidPrecision = errors.New("precision must be in [4, 18]")
+
+func NewHyperLogLog[K comparable](precision uint8, opts ...hash_table.Option[K]) (*HyperLogLog[K], error) {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, ErrInvalidPrecision
+	}
+	return &HyperLogLog[K]{
+		hashing:   newHashing(opts),
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}, nil
+}
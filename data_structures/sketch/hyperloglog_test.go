@@ -0,0 +1,94 @@
+package sketch
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"math"
+	"testing"
+)
+
+func TestNewHyperLogLog(t *testing.T) {
+	_, err := NewHyperLogLog[int](MinPrecision - 1)
+	assert.ErrorIs(t, err, ErrInvalidPrecision)
+	_, err = NewHyperLogLog[int](MaxPrecision + 1)
+	assert.ErrorIs(t, err, ErrInvalidPrecision)
+
+	s, err := NewHyperLogLog[int](10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(10), s.Precision())
+	assert.Zero(t, s.Count())
+}
+
+func TestHyperLogLog_Count(t *testing.T) {
+	for _, n := range []int{10, 1000, 100000} {
+		for _, precision := range []uint8{10, 14} {
+			t.Run(fmt.Sprintf("%d/%d", n, precision), func(t *testing.T) {
+				s, _ := NewHyperLogLog[int](precision, hash_table.WithHasher[int](hash_table.IntegerHasher[int]{}))
+				for i := 0; i < n; i++ {
+					// duplicates must not change the estimate
+					assert.NoError(t, s.Add(i))
+					assert.NoError(t, s.Add(i))
+				}
+				stdErr := 1.04 / math.Sqrt(float64(uint64(1)<<precision))
+				assert.InEpsilon(t, n, s.Count(), 4*stdErr)
+			})
+		}
+	}
+}
+
+func TestHyperLogLog_Merge(t *testing.T) {
+	a, _ := NewHyperLogLog[string](12)
+	b, _ := NewHyperLogLog[string](12)
+	for i := 0; i < 6000; i++ {
+		_ = a.Add(fmt.Sprint(i))
+		_ = b.Add(fmt.Sprint(i + 4000))
+	}
+	assert.NoError(t, a.Merge(b))
+	assert.InEpsilon(t, 10000, a.Count(), 0.05)
+
+	c, _ := NewHyperLogLog[string](10)
+	assert.ErrorIs(t, a.Merge(c), ErrIncompatibleSketches)
+
+	a.Clear()
+	assert.Zero(t, a.Count())
+}
+
+func TestHyperLogLog_MarshalBinary(t *testing.T) {
+	s, _ := NewHyperLogLog[string](8)
+	for i := 0; i < 100; i++ {
+		_ = s.Add(fmt.Sprint(i))
+	}
+	data, err := s.MarshalBinary()
+	assert.NoError(t, err)
+
+	var got HyperLogLog[string]
+	assert.NoError(t, got.UnmarshalBinary(data))
+	assert.Equal(t, s.Count(), got.Count())
+	_ = got.Add("new")
+	assert.NoError(t, got.Merge(s))
+
+	tests := map[string][]byte{
+		"empty":         nil,
+		"other kind":    {kindCountMin, 8},
+		"bad precision": {kindHyperLogLog, 2},
+		"short payload": data[:len(data)-1],
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got HyperLogLog[string]
+			assert.ErrorIs(t, got.UnmarshalBinary(data), ErrInvalidData)
+		})
+	}
+}
+
+func TestHyperLogLog_HasherError(t *testing.T) {
+	h := hash_table.MockHasher[int]{}
+	h.On("Hash", mock.AnythingOfType("int")).
+		Return(uint64(0), errors.New("test err"))
+	s, _ := NewHyperLogLog[int](4, hash_table.WithHasher[int](&h))
+	assert.Error(t, s.Add(1))
+	assert.Zero(t, s.Count())
+}
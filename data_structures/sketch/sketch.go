@@ -0,0 +1,54 @@
+package sketch
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"encoding/binary"
+	"errors"
+)
+
+type hashing[K comparable] struct {
+	hasher hash_table.Hasher[K]
+}
+
+// newHashing takes the hasher from hash_table.WithHasher, hash_table.FNVHasher by default,
+// sketches can be merged only when they use the same hasher
+func newHashing[K comparable](opts []hash_table.Option[K]) hashing[K] {
+	return hashing[K]{hasher: hash_table.HasherFrom(opts...)}
+}
+
+// hash returns the key hash passed through hash_table.Mix, zero value sketches get the default hasher
+func (h *hashing[K]) hash(key K) (uint64, error) {
+	if h.hasher == nil {
+		h.hasher = hash_table.HasherFrom[K]()
+	}
+	v, err := h.hasher.Hash(key)
+	if err != nil {
+		return 0, err
+	}
+	return hash_table.Mix(v), nil
+}
+
+var (
+	ErrIncompatibleSketches = errors.New("sketches must have the same size")
+	ErrInvalidData          = errors.New("invalid sketch data")
+)
+
+// binary layout starts with the kind byte, numbers are little endian.
+// The hasher isn't encoded, a decoded sketch keeps the one of the receiver
+// and a zero value sketch gets hash_table.FNVHasher on first use
+const (
+	kindCountMin byte = iota + 1
+	kindHyperLogLog
+	kindTopK
+)
+
+func readUint64(data []byte, n int) ([]uint64, []byte, error) {
+	if n < 0 || len(data)/8 < n {
+		return nil, nil, ErrInvalidData
+	}
+	values := make([]uint64, n)
+	for i := range values {
+		values[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	return values, data[n*8:], nil
+}
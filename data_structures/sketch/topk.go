@@ -0,0 +1,184 @@
+package sketch
+
+import (
+	"algoritms_and_structures/data_structures/hash_table"
+	"bytes"
+	"cmp"
+	"container/heap"
+	"encoding/gob"
+	"errors"
+	"slices"
+)
+
+type HeavyHitter[K comparable] struct {
+	Key   K
+	Count uint64
+}
+
+// hitters is a min heap by count, index keeps the heap position of every key
+type hitters[K comparable] struct {
+	items []HeavyHitter[K]
+	index map[K]int
+}
+
+func (h *hitters[K]) Len() int {
+	return len(h.items)
+}
+
+func (h *hitters[K]) Less(i, j int) bool {
+	return h.items[i].Count < h.items[j].Count
+}
+
+func (h *hitters[K]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.index[h.items[i].Key] = i
+	h.index[h.items[j].Key] = j
+}
+
+func (h *hitters[K]) Push(x any) {
+	hh := x.(HeavyHitter[K])
+	h.index[hh.Key] = len(h.items)
+	h.items = append(h.items, hh)
+}
+
+func (h *hitters[K]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	delete(h.index, last.Key)
+	return last
+}
+
+// TopK tracks the k keys with the largest count-min estimates
+type TopK[K comparable] struct {
+	k       int
+	sketch  *CountMin[K]
+	hitters hitters[K]
+}
+
+func (t *TopK[K]) K() int {
+	return t.k
+}
+
+func (t *TopK[K]) Sketch() *CountMin[K] {
+	return t.sketch
+}
+
+func (t *TopK[K]) Add(key K, count uint64) error {
+	if err := t.sketch.Add(key, count); err != nil {
+		return err
+	}
+	est, _ := t.sketch.Estimate(key)
+	t.offer(HeavyHitter[K]{Key: key, Count: est})
+	return nil
+}
+
+func (t *TopK[K]) offer(hh HeavyHitter[K]) {
+	h := &t.hitters
+	if i, ok := h.index[hh.Key]; ok {
+		h.items[i].Count = hh.Count
+		heap.Fix(h, i)
+		return
+	}
+	if h.Len() < t.k {
+		heap.Push(h, hh)
+		return
+	}
+	if hh.Count > h.items[0].Count {
+		delete(h.index, h.items[0].Key)
+		h.items[0] = hh
+		h.index[hh.Key] = 0
+		heap.Fix(h, 0)
+	}
+}
+
+// List returns the heavy hitters from the most frequent
+func (t *TopK[K]) List() []HeavyHitter[K] {
+	list := slices.Clone(t.hitters.items)
+	slices.SortStableFunc(list, func(a, b HeavyHitter[K]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+	return list
+}
+
+// Merge combines the sketches and picks the top k among the keys of both
+func (t *TopK[K]) Merge(other *TopK[K]) error {
+	if t.k != other.k {
+		return ErrIncompatibleSketches
+	}
+	if err := t.sketch.Merge(other.sketch); err != nil {
+		return err
+	}
+	return t.rebuild(append(t.keys(), other.keys()...))
+}
+
+func (t *TopK[K]) keys() []K {
+	keys := make([]K, 0, len(t.hitters.items))
+	for _, hh := range t.hitters.items {
+		keys = append(keys, hh.Key)
+	}
+	return keys
+}
+
+func (t *TopK[K]) rebuild(keys []K) error {
+	t.hitters = hitters[K]{index: make(map[K]int, t.k)}
+	for _, key := range keys {
+		est, err := t.sketch.Estimate(key)
+		if err != nil {
+			return err
+		}
+		t.offer(HeavyHitter[K]{Key: key, Count: est})
+	}
+	return nil
+}
+
+type encodedTopK[K comparable] struct {
+	K      int
+	Sketch []byte
+	Keys   []K
+}
+
+// MarshalBinary encodes the keys with gob, so K must be supported by gob
+func (t *TopK[K]) MarshalBinary() ([]byte, error) {
+	sketch, err := t.sketch.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(kindTopK)
+	err = gob.NewEncoder(&buf).Encode(encodedTopK[K]{K: t.k, Sketch: sketch, Keys: t.keys()})
+	return buf.Bytes(), err
+}
+
+func (t *TopK[K]) UnmarshalBinary(data []byte) error {
+	if len(data) < 1 || data[0] != kindTopK {
+		return ErrInvalidData
+	}
+	var et encodedTopK[K]
+	if err := gob.NewDecoder(bytes.NewReader(data[1:])).Decode(&et); err != nil {
+		return errors.Join(ErrInvalidData, err)
+	}
+	if et.K < 1 {
+		return ErrInvalidData
+	}
+	if t.sketch == nil {
+		t.sketch = new(CountMin[K])
+	}
+	if err := t.sketch.UnmarshalBinary(et.Sketch); err != nil {
+		return err
+	}
+	t.k = et.K
+	return t.rebuild(et.Keys)
+}
+
+var ErrKIsEmpty = errors.New("k cannot be 0")
+
+func NewTopK[K comparable](k int, epsilon, delta float64, opts ...hash_table.Option[K]) (*TopK[K], error) {
+	if k < 1 {
+		return nil, ErrKIsEmpty
+	}
+	sketch, err := NewCountMin[K](epsilon, delta, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &TopK[K]{k: k, sketch: sketch, hitters: hitters[K]{index: make(map[K]int, k)}}, nil
+}
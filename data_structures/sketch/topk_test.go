@@ -0,0 +1,76 @@
+package sketch
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewTopK(t *testing.T) {
+	_, err := NewTopK[string](0, 0.01, 0.01)
+	assert.ErrorIs(t, err, ErrKIsEmpty)
+	_, err = NewTopK[string](3, 0, 0.01)
+	assert.ErrorIs(t, err, ErrInvalidErrorBound)
+}
+
+func fetchTopK(t *testing.T, counts map[string]uint64) *TopK[string] {
+	t.Helper()
+	s, err := NewTopK[string](3, 0.001, 0.01)
+	assert.NoError(t, err)
+	for key, count := range counts {
+		for i := uint64(0); i < count; i++ {
+			assert.NoError(t, s.Add(key, 1))
+		}
+	}
+	return s
+}
+
+func TestTopK_List(t *testing.T) {
+	s := fetchTopK(t, map[string]uint64{"a": 50, "b": 40, "c": 30, "d": 20, "e": 10, "f": 1})
+	assert.Equal(t, 3, s.K())
+	assert.Equal(t, []HeavyHitter[string]{
+		{Key: "a", Count: 50},
+		{Key: "b", Count: 40},
+		{Key: "c", Count: 30},
+	}, s.List())
+
+	// a late burst replaces the smallest hitter
+	assert.NoError(t, s.Add("f", 100))
+	assert.Equal(t, []HeavyHitter[string]{
+		{Key: "f", Count: 101},
+		{Key: "a", Count: 50},
+		{Key: "b", Count: 40},
+	}, s.List())
+	assert.Equal(t, uint64(251), s.Sketch().Total())
+}
+
+func TestTopK_Merge(t *testing.T) {
+	a := fetchTopK(t, map[string]uint64{"a": 10, "b": 9, "c": 8})
+	b := fetchTopK(t, map[string]uint64{"c": 5, "d": 15, "e": 1})
+	assert.NoError(t, a.Merge(b))
+	assert.Equal(t, []HeavyHitter[string]{
+		{Key: "d", Count: 15},
+		{Key: "c", Count: 13},
+		{Key: "a", Count: 10},
+	}, a.List())
+
+	c, _ := NewTopK[string](5, 0.001, 0.01)
+	assert.ErrorIs(t, a.Merge(c), ErrIncompatibleSketches)
+	d, _ := NewTopK[string](3, 0.1, 0.01)
+	assert.ErrorIs(t, a.Merge(d), ErrIncompatibleSketches)
+}
+
+func TestTopK_MarshalBinary(t *testing.T) {
+	s := fetchTopK(t, map[string]uint64{"a": 3, "b": 2, "c": 1, "d": 1})
+	data, err := s.MarshalBinary()
+	assert.NoError(t, err)
+
+	var got TopK[string]
+	assert.NoError(t, got.UnmarshalBinary(data))
+	assert.Equal(t, s.K(), got.K())
+	assert.Equal(t, s.List()[:2], got.List()[:2])
+	assert.NoError(t, got.Add("c", 5))
+	assert.Equal(t, HeavyHitter[string]{Key: "c", Count: 6}, got.List()[0])
+
+	assert.ErrorIs(t, got.UnmarshalBinary(nil), ErrInvalidData)
+	assert.ErrorIs(t, got.UnmarshalBinary(data[:len(data)-3]), ErrInvalidData)
+}
@@ -1,6 +1,6 @@
 module algoritms_and_structures
 
-go 1.21
+go 1.23
 
 require github.com/stretchr/testify v1.9.0
 